@@ -0,0 +1,119 @@
+package auction
+
+import (
+	"backend/config"
+	"backend/entities"
+	"backend/entities/auction_status"
+	"backend/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+func Status(tender entities.Tender, now time.Time) string {
+	if tender.AuctionStartsAt == nil || tender.AuctionEndsAt == nil {
+		return ""
+	}
+	if now.Before(*tender.AuctionStartsAt) {
+		return auction_status.SCHEDULED
+	}
+	if now.Before(*tender.AuctionEndsAt) {
+		return auction_status.ACTIVE
+	}
+	return auction_status.FINISHED
+}
+
+func ExtendedEnd(tender entities.Tender, now time.Time) (time.Time, bool) {
+	if Status(tender, now) != auction_status.ACTIVE || tender.AuctionExtension <= 0 {
+		return time.Time{}, false
+	}
+	extension := time.Duration(tender.AuctionExtension) * time.Second
+	if tender.AuctionEndsAt.Sub(now) >= extension {
+		return time.Time{}, false
+	}
+	return now.Add(extension), true
+}
+
+type Worker struct {
+	s        *storage.Storage
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+	running  atomic.Bool
+	lastTick atomic.Int64
+}
+
+func NewWorker(s *storage.Storage, cfg *config.Config) *Worker {
+	return &Worker{
+		s:        s,
+		interval: cfg.GetAuctionConfig().GetPollInterval(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (w *Worker) Start(context.Context) error {
+	go w.run()
+	return nil
+}
+
+func (w *Worker) Stop(ctx context.Context) error {
+	close(w.stop)
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Check reports whether the worker loop is running and keeps ticking.
+func (w *Worker) Check(context.Context) error {
+	if !w.running.Load() {
+		return errors.New("not running")
+	}
+	if lastTick := time.Unix(0, w.lastTick.Load()); time.Since(lastTick) > 3*w.interval {
+		return fmt.Errorf("stalled, last tick at %s", lastTick.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (w *Worker) run() {
+	defer close(w.done)
+	w.running.Store(true)
+	defer w.running.Store(false)
+	w.lastTick.Store(time.Now().UnixNano())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-w.stop
+		cancel()
+	}()
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.finishAuctions(ctx)
+			w.lastTick.Store(time.Now().UnixNano())
+		}
+	}
+}
+
+func (w *Worker) finishAuctions(ctx context.Context) {
+	tenderIds, err := w.s.GetFinishedAuctions(ctx, time.Now().UTC())
+	if err != nil {
+		slog.ErrorContext(ctx, "can't get finished auctions", "component", "auction", "error", err)
+		return
+	}
+	for _, tenderId := range tenderIds {
+		if err := w.s.FinishAuction(ctx, tenderId); err != nil {
+			slog.ErrorContext(ctx, "can't finish auction", "component", "auction", "tender_id", tenderId, "error", err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package auction
+
+import (
+	"backend/entities"
+	"backend/entities/auction_status"
+	"testing"
+	"time"
+)
+
+var (
+	startsAt = time.Date(2024, 9, 21, 10, 0, 0, 0, time.UTC)
+	endsAt   = startsAt.Add(time.Hour)
+)
+
+func newTender(extension int) entities.Tender {
+	return entities.Tender{AuctionStartsAt: &startsAt, AuctionEndsAt: &endsAt, AuctionExtension: extension}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		tender entities.Tender
+		now    time.Time
+		want   string
+	}{
+		{"no auction", entities.Tender{}, startsAt, ""},
+		{"only start", entities.Tender{AuctionStartsAt: &startsAt}, startsAt, ""},
+		{"before start", newTender(0), startsAt.Add(-time.Second), auction_status.SCHEDULED},
+		{"at start", newTender(0), startsAt, auction_status.ACTIVE},
+		{"before end", newTender(0), endsAt.Add(-time.Second), auction_status.ACTIVE},
+		{"at end", newTender(0), endsAt, auction_status.FINISHED},
+		{"after end", newTender(0), endsAt.Add(time.Hour), auction_status.FINISHED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Status(tt.tender, tt.now); got != tt.want {
+				t.Errorf("Status() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtendedEnd(t *testing.T) {
+	tests := []struct {
+		name     string
+		tender   entities.Tender
+		now      time.Time
+		want     time.Time
+		extended bool
+	}{
+		{"no auction", entities.Tender{AuctionExtension: 60}, startsAt, time.Time{}, false},
+		{"no extension", newTender(0), endsAt.Add(-time.Second), time.Time{}, false},
+		{"scheduled", newTender(60), startsAt.Add(-time.Second), time.Time{}, false},
+		{"finished", newTender(60), endsAt, time.Time{}, false},
+		{"far from end", newTender(60), endsAt.Add(-2 * time.Minute), time.Time{}, false},
+		{"exactly extension before end", newTender(60), endsAt.Add(-time.Minute), time.Time{}, false},
+		{"close to end", newTender(60), endsAt.Add(-10 * time.Second), endsAt.Add(50 * time.Second), true},
+		{"last second", newTender(60), endsAt.Add(-time.Nanosecond), endsAt.Add(time.Minute - time.Nanosecond), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, extended := ExtendedEnd(tt.tender, tt.now)
+			if extended != tt.extended || !got.Equal(tt.want) {
+				t.Errorf("ExtendedEnd() = %v, %v, want %v, %v", got, extended, tt.want, tt.extended)
+			}
+		})
+	}
+}
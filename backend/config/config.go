@@ -1,42 +1,108 @@
 package config
 
 import (
-	"backend/db"
-	"database/sql"
+	"errors"
+	"fmt"
 	"gopkg.in/yaml.v3"
+	"io/fs"
 	"os"
 )
 
+const DefaultPath = "config.yaml"
+
+// values is the layout of the configuration file. Environment variables and
+// the printed configuration follow the same layout.
+type values struct {
+	Postgres      ConfigDB            `yaml:"postgres"`
+	Auction       ConfigAuction       `yaml:"auction"`
+	Events        ConfigEvents        `yaml:"events"`
+	Notifications ConfigNotifications `yaml:"notifications"`
+	GRPC          ConfigGRPC          `yaml:"grpc"`
+	HTTP          ConfigHTTP          `yaml:"http"`
+	Metrics       ConfigMetrics       `yaml:"metrics"`
+	Tracing       ConfigTracing       `yaml:"tracing"`
+	Log           ConfigLog           `yaml:"log"`
+	Health        ConfigHealth        `yaml:"health"`
+	RateLimit     ConfigRateLimit     `yaml:"rate_limit"`
+	Idempotency   ConfigIdempotency   `yaml:"idempotency"`
+}
+
 type Config struct {
-	db *sql.DB
+	v values
+}
+
+func (c Config) GetDBConfig() ConfigDB {
+	return c.v.Postgres
+}
+
+func (c Config) GetAuctionConfig() ConfigAuction {
+	return c.v.Auction
 }
 
-func (c Config) GetDB() *sql.DB {
-	return c.db
+func (c Config) GetEventsConfig() ConfigEvents {
+	return c.v.Events
+}
+
+func (c Config) GetNotificationsConfig() ConfigNotifications {
+	return c.v.Notifications
+}
+
+func (c Config) GetGRPCConfig() ConfigGRPC {
+	return c.v.GRPC
+}
+
+func (c Config) GetHTTPConfig() ConfigHTTP {
+	return c.v.HTTP
+}
+
+func (c Config) GetMetricsConfig() ConfigMetrics {
+	return c.v.Metrics
+}
+
+func (c Config) GetTracingConfig() ConfigTracing {
+	return c.v.Tracing
+}
+
+func (c Config) GetLogConfig() ConfigLog {
+	return c.v.Log
+}
+
+func (c Config) GetHealthConfig() ConfigHealth {
+	return c.v.Health
+}
+
+func (c Config) GetRateLimitConfig() ConfigRateLimit {
+	return c.v.RateLimit
+}
+
+func (c Config) GetIdempotencyConfig() ConfigIdempotency {
+	return c.v.Idempotency
 }
 
 func (c Config) GetServerAddress() string {
-	return os.Getenv("SERVER_ADDRESS")
+	return c.v.HTTP.GetAddress()
 }
 
-func NewConfig() *Config {
-	type cfg struct {
-		PostgresConfig ConfigDB `yaml:"postgres"`
-	}
-	yamlFile, err := os.ReadFile("config.yaml")
-	if err != nil {
-		panic(err)
-	}
-	var c cfg
-	if err := yaml.Unmarshal(yamlFile, &c); err != nil {
-		panic(err)
+// Load builds the configuration from defaults, then the YAML file at path, then
+// environment variables. A missing file is only an error when it is not the
+// default one.
+func Load(path string) (*Config, error) {
+	v := defaults()
+	file, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(file, &v); err != nil {
+			return nil, fmt.Errorf("config %s: %w", path, err)
+		}
+	case errors.Is(err, fs.ErrNotExist) && path == DefaultPath:
+	default:
+		return nil, fmt.Errorf("config: %w", err)
 	}
-	conn, err := db.ConnectDB(c.PostgresConfig)
-	if err != nil {
-		panic(err)
+	if err := applyEnv(&v); err != nil {
+		return nil, err
 	}
-	if err := conn.Ping(); err != nil {
-		panic(err)
+	if err := validate(v); err != nil {
+		return nil, err
 	}
-	return &Config{db: conn}
+	return &Config{v: v}, nil
 }
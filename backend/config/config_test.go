@@ -0,0 +1,158 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadLayers(t *testing.T) {
+	tests := []struct {
+		name           string
+		yaml           string
+		env            map[string]string
+		dsn            string
+		address        string
+		requestTimeout time.Duration
+	}{
+		{
+			name:           "defaults and env",
+			env:            map[string]string{"POSTGRES_DSN": "postgres://env"},
+			dsn:            "postgres://env",
+			address:        ":8080",
+			requestTimeout: 10 * time.Second,
+		},
+		{
+			name:           "yaml overrides defaults",
+			yaml:           "postgres:\n  dsn: postgres://yaml\nhttp:\n  address: \":9000\"\n  request_timeout: 3s\n",
+			dsn:            "postgres://yaml",
+			address:        ":9000",
+			requestTimeout: 3 * time.Second,
+		},
+		{
+			name:           "env overrides yaml",
+			yaml:           "postgres:\n  dsn: postgres://yaml\nhttp:\n  address: \":9000\"\n",
+			env:            map[string]string{"HTTP_ADDRESS": ":7000", "HTTP_REQUEST_TIMEOUT": "1m"},
+			dsn:            "postgres://yaml",
+			address:        ":7000",
+			requestTimeout: time.Minute,
+		},
+		{
+			name:           "legacy env",
+			env:            map[string]string{"POSTGRES_CONN": "postgres://legacy", "SERVER_ADDRESS": ":6000"},
+			dsn:            "postgres://legacy",
+			address:        ":6000",
+			requestTimeout: 10 * time.Second,
+		},
+		{
+			name:           "current env wins over legacy",
+			env:            map[string]string{"POSTGRES_CONN": "postgres://legacy", "POSTGRES_DSN": "postgres://env"},
+			dsn:            "postgres://env",
+			address:        ":8080",
+			requestTimeout: 10 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			path := DefaultPath
+			if len(tt.yaml) > 0 {
+				path = writeConfig(t, tt.yaml)
+			}
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := cfg.GetDBConfig().GetDSN(); got != tt.dsn {
+				t.Errorf("dsn = %q, want %q", got, tt.dsn)
+			}
+			if got := cfg.GetHTTPConfig().GetAddress(); got != tt.address {
+				t.Errorf("address = %q, want %q", got, tt.address)
+			}
+			if got := cfg.GetHTTPConfig().GetRequestTimeout(); got != tt.requestTimeout {
+				t.Errorf("request timeout = %v, want %v", got, tt.requestTimeout)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		env  map[string]string
+		want string
+		// prefix compares only the beginning of errors that quote a parser.
+		prefix bool
+	}{
+		{
+			name: "missing dsn",
+			want: "invalid config:\n  postgres.dsn: is required",
+		},
+		{
+			name: "unknown value",
+			yaml: "postgres:\n  dsn: postgres://yaml\nlog:\n  level: verbose\n",
+			want: "invalid config:\n  log.level: must be one of debug, info, warn, error, got \"verbose\"",
+		},
+		{
+			name: "several keys at once",
+			yaml: "http:\n  address: nope\ntracing:\n  exporter: otlp\n  endpoint: \"\"\n",
+			want: "invalid config:\n  postgres.dsn: is required\n" +
+				"  http.address: must be host:port, got \"nope\"\n" +
+				"  tracing.endpoint: is required when exporter is otlp",
+		},
+		{
+			name: "burst without rate",
+			yaml: "postgres:\n  dsn: postgres://yaml\nrate_limit:\n  read:\n    ip:\n      rate: 1\n      burst: 0\n",
+			want: "invalid config:\n  rate_limit.read.ip.burst: is required unless rate is 0",
+		},
+		{
+			name: "bad env value",
+			env:  map[string]string{"POSTGRES_DSN": "postgres://env", "HTTP_REQUEST_TIMEOUT": "soon"},
+			want: "env HTTP_REQUEST_TIMEOUT: can't parse \"soon\" as time.Duration",
+		},
+		{
+			name:   "broken yaml",
+			yaml:   "postgres: [",
+			want:   "config ",
+			prefix: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			path := DefaultPath
+			if len(tt.yaml) > 0 {
+				path = writeConfig(t, tt.yaml)
+			}
+			_, err := Load(path)
+			if err == nil {
+				t.Fatal("Load() error = nil")
+			}
+			if got := err.Error(); got != tt.want && !(tt.prefix && strings.HasPrefix(got, tt.want)) {
+				t.Errorf("Load() error = %q, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil || strings.HasPrefix(err.Error(), "invalid config") {
+		t.Errorf("Load() of missing file: error = %v, want read error", err)
+	}
+}
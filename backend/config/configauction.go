@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+type ConfigAuction struct {
+	PollInterval time.Duration `yaml:"poll_interval" validate:"gt=0"`
+}
+
+func (c ConfigAuction) GetPollInterval() time.Duration {
+	return c.PollInterval
+}
@@ -1,19 +1,24 @@
 package config
 
 import (
-	"os"
+	"database/sql"
 	"time"
 )
 
 type ConfigDB struct {
-	MaxOpenConns    int           `yaml:"max_open_conns"`
-	MaxIdleConns    int           `yaml:"max_idle_conns"`
-	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
-	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+	DSN             string        `yaml:"dsn" validate:"required" secret:"true"`
+	MaxOpenConns    int           `yaml:"max_open_conns" validate:"gte=0"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" validate:"gte=0"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" validate:"gte=0"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" validate:"gte=0"`
+	QueryTimeout    time.Duration `yaml:"query_timeout" validate:"gte=0"`
+	TxIsolation     string        `yaml:"tx_isolation" validate:"oneof=read_committed repeatable_read serializable"`
+	TxMaxRetries    int           `yaml:"tx_max_retries" validate:"gte=0"`
+	TxRetryBackoff  time.Duration `yaml:"tx_retry_backoff" validate:"gte=0"`
 }
 
 func (c ConfigDB) GetDSN() string {
-	return os.Getenv("POSTGRES_CONN")
+	return c.DSN
 }
 
 func (c ConfigDB) GetMaxOpenConnections() int {
@@ -31,3 +36,26 @@ func (c ConfigDB) GetConnectionMaxLifetime() time.Duration {
 func (c ConfigDB) GetConnectionMaxIdleTime() time.Duration {
 	return c.ConnMaxIdleTime
 }
+
+func (c ConfigDB) GetQueryTimeout() time.Duration {
+	return c.QueryTimeout
+}
+
+func (c ConfigDB) GetTxIsolation() sql.IsolationLevel {
+	switch c.TxIsolation {
+	case "read_committed":
+		return sql.LevelReadCommitted
+	case "serializable":
+		return sql.LevelSerializable
+	default:
+		return sql.LevelRepeatableRead
+	}
+}
+
+func (c ConfigDB) GetTxMaxRetries() int {
+	return c.TxMaxRetries
+}
+
+func (c ConfigDB) GetTxRetryBackoff() time.Duration {
+	return c.TxRetryBackoff
+}
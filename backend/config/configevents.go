@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+type ConfigEvents struct {
+	HistorySize       int           `yaml:"history_size" validate:"gte=0"`
+	BufferSize        int           `yaml:"buffer_size" validate:"gt=0"`
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval" validate:"gt=0"`
+}
+
+func (c ConfigEvents) GetHistorySize() int {
+	return c.HistorySize
+}
+
+func (c ConfigEvents) GetBufferSize() int {
+	return c.BufferSize
+}
+
+func (c ConfigEvents) GetHeartbeatInterval() time.Duration {
+	return c.HeartbeatInterval
+}
@@ -0,0 +1,18 @@
+package config
+
+import "time"
+
+type ConfigGRPC struct {
+	Address         string        `yaml:"address" validate:"required,hostname_port"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" validate:"gt=0"`
+}
+
+func (c ConfigGRPC) GetAddress() string {
+	return c.Address
+}
+
+// GetShutdownTimeout returns how long in-flight RPCs may run on shutdown
+// before the server stops forcibly.
+func (c ConfigGRPC) GetShutdownTimeout() time.Duration {
+	return c.ShutdownTimeout
+}
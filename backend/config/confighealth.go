@@ -0,0 +1,18 @@
+package config
+
+import "time"
+
+type ConfigHealth struct {
+	CheckTimeout  time.Duration `yaml:"check_timeout" validate:"gt=0"`
+	ShutdownDelay time.Duration `yaml:"shutdown_delay" validate:"gte=0"`
+}
+
+func (c ConfigHealth) GetCheckTimeout() time.Duration {
+	return c.CheckTimeout
+}
+
+// GetShutdownDelay returns how long /readyz reports not ready before the HTTP
+// server stops accepting connections, so that load balancers can drain it.
+func (c ConfigHealth) GetShutdownDelay() time.Duration {
+	return c.ShutdownDelay
+}
@@ -0,0 +1,30 @@
+package config
+
+import "time"
+
+type ConfigHTTP struct {
+	Address         string                   `yaml:"address" validate:"required,hostname_port"`
+	RequestTimeout  time.Duration            `yaml:"request_timeout" validate:"gte=0"`
+	RouteTimeouts   map[string]time.Duration `yaml:"route_timeouts" validate:"dive,keys,route,endkeys,gte=0"`
+	ShutdownTimeout time.Duration            `yaml:"shutdown_timeout" validate:"gt=0"`
+}
+
+func (c ConfigHTTP) GetAddress() string {
+	return c.Address
+}
+
+func (c ConfigHTTP) GetRequestTimeout() time.Duration {
+	return c.RequestTimeout
+}
+
+// GetRouteTimeouts returns timeouts keyed by "METHOD /path/:param". They
+// override the request timeout, zero disables the timeout for the route.
+func (c ConfigHTTP) GetRouteTimeouts() map[string]time.Duration {
+	return c.RouteTimeouts
+}
+
+// GetShutdownTimeout returns how long in-flight requests may run on shutdown
+// before their connections are closed.
+func (c ConfigHTTP) GetShutdownTimeout() time.Duration {
+	return c.ShutdownTimeout
+}
@@ -0,0 +1,25 @@
+package config
+
+import "time"
+
+type ConfigIdempotency struct {
+	TTL             time.Duration `yaml:"ttl" validate:"gt=0"`
+	LockTimeout     time.Duration `yaml:"lock_timeout" validate:"gt=0"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval" validate:"gt=0"`
+}
+
+// GetTTL returns how long a key keeps its stored response. After that the
+// same key is treated as a new request.
+func (c ConfigIdempotency) GetTTL() time.Duration {
+	return c.TTL
+}
+
+// GetLockTimeout returns how long a key stays reserved by a request that
+// hasn't stored its response. It should be longer than any request runs.
+func (c ConfigIdempotency) GetLockTimeout() time.Duration {
+	return c.LockTimeout
+}
+
+func (c ConfigIdempotency) GetCleanupInterval() time.Duration {
+	return c.CleanupInterval
+}
@@ -0,0 +1,15 @@
+package config
+
+import "log/slog"
+
+type ConfigLog struct {
+	Level string `yaml:"level" validate:"oneof=debug info warn error"`
+}
+
+func (c ConfigLog) GetLevel() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Level)); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
@@ -0,0 +1,14 @@
+package config
+
+type ConfigMetrics struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path" validate:"required,startswith=/"`
+}
+
+func (c ConfigMetrics) IsEnabled() bool {
+	return c.Enabled
+}
+
+func (c ConfigMetrics) GetPath() string {
+	return c.Path
+}
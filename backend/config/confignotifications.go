@@ -0,0 +1,38 @@
+package config
+
+import (
+	"net"
+	"strconv"
+	"time"
+)
+
+type ConfigSMTP struct {
+	Host     string `yaml:"host" validate:"required"`
+	Port     int    `yaml:"port" validate:"min=1,max=65535"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password" secret:"true"`
+	From     string `yaml:"from" validate:"required"`
+}
+
+func (c ConfigSMTP) GetAddress() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
+type ConfigNotifications struct {
+	SMTP            ConfigSMTP    `yaml:"smtp"`
+	DefaultLanguage string        `yaml:"default_language" validate:"oneof=ru en"`
+	DeadlineWarning time.Duration `yaml:"deadline_warning" validate:"gt=0"`
+	PollInterval    time.Duration `yaml:"poll_interval" validate:"gt=0"`
+}
+
+func (c ConfigNotifications) GetDefaultLanguage() string {
+	return c.DefaultLanguage
+}
+
+func (c ConfigNotifications) GetDeadlineWarning() time.Duration {
+	return c.DeadlineWarning
+}
+
+func (c ConfigNotifications) GetPollInterval() time.Duration {
+	return c.PollInterval
+}
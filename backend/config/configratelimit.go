@@ -0,0 +1,72 @@
+package config
+
+// ConfigRate is a token bucket: Burst requests at once, refilled at Rate
+// requests per second. Zero rate disables the limit.
+type ConfigRate struct {
+	Rate  float64 `yaml:"rate" validate:"gte=0"`
+	Burst int     `yaml:"burst" validate:"required_unless=Rate 0,gte=0"`
+}
+
+func (c ConfigRate) GetRate() float64 {
+	return c.Rate
+}
+
+func (c ConfigRate) GetBurst() int {
+	return c.Burst
+}
+
+func (c ConfigRate) IsEnabled() bool {
+	return c.Rate > 0
+}
+
+type ConfigRateBudget struct {
+	IP   ConfigRate `yaml:"ip"`
+	User ConfigRate `yaml:"user"`
+}
+
+type ConfigRedis struct {
+	Address  string `yaml:"address" validate:"omitempty,hostname_port"`
+	Password string `yaml:"password" secret:"true"`
+	DB       int    `yaml:"db" validate:"gte=0"`
+}
+
+func (c ConfigRedis) GetAddress() string {
+	return c.Address
+}
+
+func (c ConfigRedis) GetPassword() string {
+	return c.Password
+}
+
+func (c ConfigRedis) GetDB() int {
+	return c.DB
+}
+
+type ConfigRateLimit struct {
+	Enabled bool             `yaml:"enabled"`
+	Backend string           `yaml:"backend" validate:"oneof=memory redis"`
+	Redis   ConfigRedis      `yaml:"redis"`
+	Read    ConfigRateBudget `yaml:"read"`
+	Write   ConfigRateBudget `yaml:"write"`
+}
+
+func (c ConfigRateLimit) IsEnabled() bool {
+	return c.Enabled
+}
+
+// GetBackend returns "memory" or "redis".
+func (c ConfigRateLimit) GetBackend() string {
+	return c.Backend
+}
+
+func (c ConfigRateLimit) GetRedis() ConfigRedis {
+	return c.Redis
+}
+
+func (c ConfigRateLimit) GetRead() ConfigRateBudget {
+	return c.Read
+}
+
+func (c ConfigRateLimit) GetWrite() ConfigRateBudget {
+	return c.Write
+}
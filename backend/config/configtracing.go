@@ -0,0 +1,30 @@
+package config
+
+type ConfigTracing struct {
+	Exporter    string  `yaml:"exporter" validate:"oneof=otlp stdout none"`
+	Endpoint    string  `yaml:"endpoint" validate:"required_if=Exporter otlp"`
+	Insecure    bool    `yaml:"insecure"`
+	ServiceName string  `yaml:"service_name" validate:"required"`
+	SampleRatio float64 `yaml:"sample_ratio" validate:"min=0,max=1"`
+}
+
+// GetExporter returns one of "otlp", "stdout" or "none".
+func (c ConfigTracing) GetExporter() string {
+	return c.Exporter
+}
+
+func (c ConfigTracing) GetEndpoint() string {
+	return c.Endpoint
+}
+
+func (c ConfigTracing) IsInsecure() bool {
+	return c.Insecure
+}
+
+func (c ConfigTracing) GetServiceName() string {
+	return c.ServiceName
+}
+
+func (c ConfigTracing) GetSampleRatio() float64 {
+	return c.SampleRatio
+}
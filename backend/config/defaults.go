@@ -0,0 +1,80 @@
+package config
+
+import "time"
+
+func defaults() values {
+	return values{
+		Postgres: ConfigDB{
+			MaxOpenConns:    5,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 10 * time.Minute,
+			ConnMaxIdleTime: time.Minute,
+			QueryTimeout:    5 * time.Second,
+			TxIsolation:     "repeatable_read",
+			TxMaxRetries:    3,
+			TxRetryBackoff:  20 * time.Millisecond,
+		},
+		Auction: ConfigAuction{
+			PollInterval: 10 * time.Second,
+		},
+		Events: ConfigEvents{
+			HistorySize:       1000,
+			BufferSize:        64,
+			HeartbeatInterval: 15 * time.Second,
+		},
+		Notifications: ConfigNotifications{
+			SMTP: ConfigSMTP{
+				Host: "localhost",
+				Port: 1025,
+				From: "tenders@localhost",
+			},
+			DefaultLanguage: "ru",
+			DeadlineWarning: 24 * time.Hour,
+			PollInterval:    time.Minute,
+		},
+		GRPC: ConfigGRPC{
+			Address:         ":9090",
+			ShutdownTimeout: 10 * time.Second,
+		},
+		HTTP: ConfigHTTP{
+			Address:         ":8080",
+			RequestTimeout:  10 * time.Second,
+			RouteTimeouts:   map[string]time.Duration{"GET /api/events": 0},
+			ShutdownTimeout: 10 * time.Second,
+		},
+		Metrics: ConfigMetrics{
+			Enabled: true,
+			Path:    "/metrics",
+		},
+		Tracing: ConfigTracing{
+			Exporter:    "none",
+			Endpoint:    "localhost:4317",
+			ServiceName: "tenders",
+			SampleRatio: 1,
+		},
+		Log: ConfigLog{
+			Level: "info",
+		},
+		Health: ConfigHealth{
+			CheckTimeout:  2 * time.Second,
+			ShutdownDelay: 5 * time.Second,
+		},
+		RateLimit: ConfigRateLimit{
+			Enabled: true,
+			Backend: "memory",
+			Read: ConfigRateBudget{
+				IP:   ConfigRate{Rate: 20, Burst: 40},
+				User: ConfigRate{Rate: 10, Burst: 20},
+			},
+			Write: ConfigRateBudget{
+				IP:   ConfigRate{Rate: 5, Burst: 10},
+				User: ConfigRate{Rate: 1, Burst: 5},
+			},
+		},
+		Idempotency: ConfigIdempotency{
+			TTL:             24 * time.Hour,
+			LockTimeout:     time.Minute,
+			CleanupInterval: 10 * time.Minute,
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// legacyEnv maps variables that were used before every key got its own
+// variable. The generated name takes precedence when both are set.
+var legacyEnv = map[string]string{
+	"POSTGRES_CONN":  "POSTGRES_DSN",
+	"SERVER_ADDRESS": "HTTP_ADDRESS",
+}
+
+// envName turns a key path like postgres.max_open_conns into
+// POSTGRES_MAX_OPEN_CONNS.
+func envName(path []string) string {
+	return strings.ToUpper(strings.Join(path, "_"))
+}
+
+// applyEnv overrides every key that has a matching environment variable.
+// Values are parsed as YAML, so durations look like "5s" and maps like
+// {"GET /api/events": 0s}.
+func applyEnv(v *values) error {
+	return walk(reflect.ValueOf(v).Elem(), nil, func(path []string, field reflect.Value, _ reflect.StructField) error {
+		name := envName(path)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			for legacy, current := range legacyEnv {
+				if current == name {
+					value, ok = os.LookupEnv(legacy)
+					name = legacy
+				}
+			}
+		}
+		if !ok {
+			return nil
+		}
+		if field.Kind() == reflect.String {
+			field.SetString(value)
+			return nil
+		}
+		parsed := reflect.New(field.Type())
+		if err := yaml.Unmarshal([]byte(value), parsed.Interface()); err != nil {
+			return fmt.Errorf("env %s: can't parse %q as %s", name, value, field.Type())
+		}
+		field.Set(parsed.Elem())
+		return nil
+	})
+}
+
+// walk calls fn for every leaf key of a config struct with its yaml path.
+func walk(
+	v reflect.Value,
+	path []string,
+	fn func(path []string, field reflect.Value, meta reflect.StructField) error,
+) error {
+	for i := 0; i < v.NumField(); i++ {
+		meta := v.Type().Field(i)
+		name, _, _ := strings.Cut(meta.Tag.Get("yaml"), ",")
+		if len(name) == 0 || name == "-" {
+			continue
+		}
+		fieldPath := append(append([]string(nil), path...), name)
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			if err := walk(field, fieldPath, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fn(fieldPath, field, meta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
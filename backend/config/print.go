@@ -0,0 +1,27 @@
+package config
+
+import (
+	"gopkg.in/yaml.v3"
+	"io"
+	"reflect"
+)
+
+const redacted = "***"
+
+// Print writes the effective configuration as YAML. Keys tagged secret are
+// replaced with *** unless empty.
+func (c Config) Print(w io.Writer) error {
+	v := c.v
+	_ = walk(reflect.ValueOf(&v).Elem(), nil, func(_ []string, field reflect.Value, meta reflect.StructField) error {
+		if meta.Tag.Get("secret") == "true" && field.Len() > 0 {
+			field.SetString(redacted)
+		}
+		return nil
+	})
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"strings"
+)
+
+var httpMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+func newValidator() *validator.Validate {
+	val := validator.New()
+	val.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		return name
+	})
+	val.RegisterValidation("route", func(fl validator.FieldLevel) bool {
+		method, path, ok := strings.Cut(fl.Field().String(), " ")
+		if !ok || !strings.HasPrefix(path, "/") {
+			return false
+		}
+		for _, m := range httpMethods {
+			if m == method {
+				return true
+			}
+		}
+		return false
+	})
+	return val
+}
+
+// validate reports every invalid key at once, one per line, as
+// "postgres.dsn: is required".
+func validate(v values) error {
+	err := newValidator().Struct(v)
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return err
+	}
+	lines := make([]string, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		_, key, _ := strings.Cut(fieldError.Namespace(), ".")
+		lines = append(lines, key+": "+describe(fieldError))
+	}
+	return errors.New("invalid config:\n  " + strings.Join(lines, "\n  "))
+}
+
+func describe(err validator.FieldError) string {
+	switch err.Tag() {
+	case "required":
+		return "is required"
+	case "required_unless":
+		field, value, _ := strings.Cut(err.Param(), " ")
+		return fmt.Sprintf("is required unless %s is %s", strings.ToLower(field), value)
+	case "required_if":
+		field, value, _ := strings.Cut(err.Param(), " ")
+		return fmt.Sprintf("is required when %s is %s", strings.ToLower(field), value)
+	case "oneof":
+		return fmt.Sprintf("must be one of %s, got %q", strings.ReplaceAll(err.Param(), " ", ", "), err.Value())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s, got %v", err.Param(), err.Value())
+	case "gte", "min":
+		return fmt.Sprintf("must be at least %s, got %v", err.Param(), err.Value())
+	case "max":
+		return fmt.Sprintf("must be at most %s, got %v", err.Param(), err.Value())
+	case "hostname_port":
+		return fmt.Sprintf("must be host:port, got %q", err.Value())
+	case "startswith":
+		return fmt.Sprintf("must start with %q", err.Param())
+	case "route":
+		return fmt.Sprintf("must look like \"METHOD /path\", got %q", err.Value())
+	default:
+		return fmt.Sprintf("failed %q check", err.Tag())
+	}
+}
@@ -0,0 +1,85 @@
+package conflict
+
+import (
+	"backend/entities"
+	"backend/entities/author_type"
+	"backend/entities/conflict_policy"
+	"backend/storage"
+	"context"
+	"strings"
+)
+
+type Result struct {
+	Conflict bool
+	Blocked  bool
+	Reason   string
+}
+
+// store is the part of the storage the checker reads.
+type store interface {
+	GetSharedResponsibles(ctx context.Context, organizationId string, otherOrganizationId string) ([]string, error)
+	CheckOrganizationResponsible(ctx context.Context, userId string, organizationId string) (bool, error)
+	GetOrganization(ctx context.Context, id string) (entities.Organization, error)
+}
+
+type Checker struct {
+	s store
+}
+
+func NewChecker(s *storage.Storage) *Checker {
+	return &Checker{s: s}
+}
+
+func (c Checker) CheckBid(ctx context.Context, tender entities.Tender, authorType string, authorId string) (Result, error) {
+	if authorType == author_type.ORGANIZATION {
+		if authorId == tender.OrganizationId {
+			return c.result(ctx, tender.OrganizationId, "organization can't bid on its own tender")
+		}
+		shared, err := c.s.GetSharedResponsibles(ctx, authorId, tender.OrganizationId)
+		if err != nil {
+			return Result{}, err
+		}
+		if len(shared) == 0 {
+			return Result{}, nil
+		}
+		return c.result(ctx, tender.OrganizationId,
+			"the bidding organization and the tender organization share responsibles: "+strings.Join(shared, ", "))
+	}
+	responsible, err := c.s.CheckOrganizationResponsible(ctx, authorId, tender.OrganizationId)
+	if err != nil {
+		return Result{}, err
+	}
+	if !responsible {
+		return Result{}, nil
+	}
+	return c.result(ctx, tender.OrganizationId, "bid author is responsible for the tender organization")
+}
+
+func (c Checker) CheckDecision(ctx context.Context, userId string, tender entities.Tender, bid entities.Bid) (Result, error) {
+	if bid.AuthorType == author_type.ORGANIZATION {
+		responsible, err := c.s.CheckOrganizationResponsible(ctx, userId, bid.AuthorId)
+		if err != nil {
+			return Result{}, err
+		}
+		if !responsible {
+			return Result{}, nil
+		}
+		return c.result(ctx, tender.OrganizationId, "user is responsible for the organization that authored the bid")
+	}
+	if bid.AuthorId != userId {
+		return Result{}, nil
+	}
+	return c.result(ctx, tender.OrganizationId, "user is the author of the bid")
+}
+
+func (c Checker) result(ctx context.Context, buyerOrganizationId string, reason string) (Result, error) {
+	buyer, err := c.s.GetOrganization(ctx, buyerOrganizationId)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{
+		Conflict: true,
+		Blocked:  buyer.ConflictPolicy != conflict_policy.FLAG,
+		Reason:   "Conflict of interest: " + reason,
+	}, nil
+}
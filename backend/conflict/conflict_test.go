@@ -0,0 +1,194 @@
+package conflict
+
+import (
+	"backend/entities"
+	"backend/entities/author_type"
+	"backend/entities/conflict_policy"
+	"context"
+	"errors"
+	"testing"
+)
+
+const (
+	buyerId    = "00000000-0000-0000-0000-000000000001"
+	supplierId = "00000000-0000-0000-0000-000000000002"
+	userId     = "00000000-0000-0000-0000-00000000000a"
+	otherId    = "00000000-0000-0000-0000-00000000000b"
+)
+
+type fakeStore struct {
+	policy       string
+	responsibles map[string][]string
+	err          error
+}
+
+func (f fakeStore) GetSharedResponsibles(_ context.Context, organizationId string, otherOrganizationId string) ([]string, error) {
+	shared := make([]string, 0)
+	for _, user := range f.responsibles[organizationId] {
+		for _, other := range f.responsibles[otherOrganizationId] {
+			if user == other {
+				shared = append(shared, user)
+			}
+		}
+	}
+	return shared, f.err
+}
+
+func (f fakeStore) CheckOrganizationResponsible(_ context.Context, userId string, organizationId string) (bool, error) {
+	for _, user := range f.responsibles[organizationId] {
+		if user == userId {
+			return true, f.err
+		}
+	}
+	return false, f.err
+}
+
+func (f fakeStore) GetOrganization(context.Context, string) (entities.Organization, error) {
+	return entities.Organization{ConflictPolicy: f.policy}, f.err
+}
+
+func TestCheckBid(t *testing.T) {
+	tender := entities.Tender{OrganizationId: buyerId}
+	tests := []struct {
+		name       string
+		store      fakeStore
+		authorType string
+		authorId   string
+		want       Result
+		wantErr    bool
+	}{
+		{
+			name:       "unrelated user",
+			store:      fakeStore{policy: conflict_policy.BLOCK, responsibles: map[string][]string{buyerId: {otherId}}},
+			authorType: author_type.USER,
+			authorId:   userId,
+			want:       Result{},
+		},
+		{
+			name:       "responsible user is blocked",
+			store:      fakeStore{policy: conflict_policy.BLOCK, responsibles: map[string][]string{buyerId: {userId}}},
+			authorType: author_type.USER,
+			authorId:   userId,
+			want: Result{
+				Conflict: true,
+				Blocked:  true,
+				Reason:   "Conflict of interest: bid author is responsible for the tender organization",
+			},
+		},
+		{
+			name:       "responsible user is flagged",
+			store:      fakeStore{policy: conflict_policy.FLAG, responsibles: map[string][]string{buyerId: {userId}}},
+			authorType: author_type.USER,
+			authorId:   userId,
+			want: Result{
+				Conflict: true,
+				Reason:   "Conflict of interest: bid author is responsible for the tender organization",
+			},
+		},
+		{
+			name:       "own organization",
+			store:      fakeStore{policy: conflict_policy.BLOCK},
+			authorType: author_type.ORGANIZATION,
+			authorId:   buyerId,
+			want: Result{
+				Conflict: true,
+				Blocked:  true,
+				Reason:   "Conflict of interest: organization can't bid on its own tender",
+			},
+		},
+		{
+			name: "organization with shared responsibles",
+			store: fakeStore{policy: conflict_policy.FLAG, responsibles: map[string][]string{
+				buyerId:    {"alice", "bob"},
+				supplierId: {"bob"},
+			}},
+			authorType: author_type.ORGANIZATION,
+			authorId:   supplierId,
+			want: Result{
+				Conflict: true,
+				Reason:   "Conflict of interest: the bidding organization and the tender organization share responsibles: bob",
+			},
+		},
+		{
+			name: "independent organization",
+			store: fakeStore{policy: conflict_policy.BLOCK, responsibles: map[string][]string{
+				buyerId:    {"alice"},
+				supplierId: {"bob"},
+			}},
+			authorType: author_type.ORGANIZATION,
+			authorId:   supplierId,
+			want:       Result{},
+		},
+		{
+			name:       "storage error",
+			store:      fakeStore{err: errors.New("connection refused")},
+			authorType: author_type.USER,
+			authorId:   userId,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Checker{s: tt.store}.CheckBid(context.Background(), tender, tt.authorType, tt.authorId)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckBid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CheckBid() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckDecision(t *testing.T) {
+	tender := entities.Tender{OrganizationId: buyerId}
+	tests := []struct {
+		name  string
+		store fakeStore
+		bid   entities.Bid
+		want  Result
+	}{
+		{
+			name:  "decision on someone else's bid",
+			store: fakeStore{policy: conflict_policy.BLOCK},
+			bid:   entities.Bid{AuthorType: author_type.USER, AuthorId: otherId},
+			want:  Result{},
+		},
+		{
+			name:  "decision on own bid",
+			store: fakeStore{policy: conflict_policy.BLOCK},
+			bid:   entities.Bid{AuthorType: author_type.USER, AuthorId: userId},
+			want: Result{
+				Conflict: true,
+				Blocked:  true,
+				Reason:   "Conflict of interest: user is the author of the bid",
+			},
+		},
+		{
+			name:  "decision on own organization's bid",
+			store: fakeStore{policy: conflict_policy.FLAG, responsibles: map[string][]string{supplierId: {userId}}},
+			bid:   entities.Bid{AuthorType: author_type.ORGANIZATION, AuthorId: supplierId},
+			want: Result{
+				Conflict: true,
+				Reason:   "Conflict of interest: user is responsible for the organization that authored the bid",
+			},
+		},
+		{
+			name:  "decision on another organization's bid",
+			store: fakeStore{policy: conflict_policy.BLOCK, responsibles: map[string][]string{supplierId: {otherId}}},
+			bid:   entities.Bid{AuthorType: author_type.ORGANIZATION, AuthorId: supplierId},
+			want:  Result{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Checker{s: tt.store}.CheckDecision(context.Background(), userId, tender, tt.bid)
+			if err != nil {
+				t.Fatalf("CheckDecision() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CheckDecision() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,14 +2,20 @@ package db
 
 import (
 	"database/sql"
-	_ "github.com/jackc/pgx/v4/stdlib"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+	"strconv"
 )
 
 func ConnectDB(cfg IConfigDB) (*sql.DB, error) {
-	db, err := sql.Open("pgx", cfg.GetDSN())
+	connConfig, err := pgx.ParseConfig(cfg.GetDSN())
 	if err != nil {
 		return nil, err
 	}
+	if timeout := cfg.GetQueryTimeout(); timeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(timeout.Milliseconds(), 10)
+	}
+	db := stdlib.OpenDB(*connConfig)
 	if err := db.Ping(); err != nil {
 		return nil, err
 	}
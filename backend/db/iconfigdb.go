@@ -8,4 +8,5 @@ type IConfigDB interface {
 	GetMaxIdleConnections() int
 	GetConnectionMaxLifetime() time.Duration
 	GetConnectionMaxIdleTime() time.Duration
+	GetQueryTimeout() time.Duration
 }
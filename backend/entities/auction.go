@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+type AuctionOffer struct {
+	Rank   int     `json:"rank"`
+	BidId  string  `json:"bidId"`
+	Amount float64 `json:"amount"`
+}
+
+type Auction struct {
+	TenderId    string         `json:"tenderId"`
+	Status      string         `json:"status"`
+	StartsAt    time.Time      `json:"startsAt"`
+	EndsAt      time.Time      `json:"endsAt"`
+	BestPrice   *float64       `json:"bestPrice,omitempty"`
+	Currency    *string        `json:"currency,omitempty"`
+	OffersCount int            `json:"offersCount"`
+	Ranking     []AuctionOffer `json:"ranking,omitempty"`
+}
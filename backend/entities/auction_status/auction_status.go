@@ -0,0 +1,7 @@
+package auction_status
+
+const (
+	SCHEDULED string = "Scheduled"
+	ACTIVE    string = "Active"
+	FINISHED  string = "Finished"
+)
@@ -5,14 +5,21 @@ import (
 )
 
 type Bid struct {
-	Id          string    `json:"id"`
-	TenderId    string    `json:"tenderId"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	AuthorType  string    `json:"authorType"`
-	AuthorId    string    `json:"authorId"`
-	Version     int       `json:"version"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	Id                 string     `json:"id"`
+	TenderId           string     `json:"tenderId"`
+	Name               string     `json:"name"`
+	Description        string     `json:"description"`
+	Status             string     `json:"status"`
+	AuthorType         string     `json:"authorType"`
+	AuthorId           string     `json:"authorId"`
+	Version            int        `json:"version"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	UpdatedAt          time.Time  `json:"updatedAt"`
+	WithdrawalReason   *string    `json:"withdrawalReason,omitempty"`
+	WithdrawnBy        *string    `json:"withdrawnBy,omitempty"`
+	WithdrawnAt        *time.Time `json:"withdrawnAt,omitempty"`
+	ConflictOfInterest bool       `json:"conflictOfInterest"`
+	Amount             *float64   `json:"amount,omitempty"`
+	OverBudget         bool       `json:"overBudget,omitempty"`
+	AuctionRank        *int       `json:"auctionRank,omitempty"`
 }
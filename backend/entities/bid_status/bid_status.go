@@ -4,4 +4,6 @@ const (
 	CREATED   string = "Created"
 	PUBLISHED string = "Published"
 	CLOSED    string = "Closed"
+	CANCELLED string = "Cancelled"
+	WITHDRAWN string = "Withdrawn"
 )
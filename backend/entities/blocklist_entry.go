@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+type BlocklistEntry struct {
+	Id             string     `json:"id"`
+	OrganizationId string     `json:"organizationId"`
+	SubjectType    string     `json:"subjectType"`
+	SubjectId      string     `json:"subjectId"`
+	Reason         string     `json:"reason"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+	CreatedBy      *string    `json:"createdBy,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
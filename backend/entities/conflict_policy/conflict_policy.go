@@ -0,0 +1,6 @@
+package conflict_policy
+
+const (
+	BLOCK string = "Block"
+	FLAG  string = "Flag"
+)
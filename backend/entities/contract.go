@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+type Contract struct {
+	Id                  string    `json:"id"`
+	TenderId            string    `json:"tenderId"`
+	TenderVersion       int       `json:"tenderVersion"`
+	BidId               string    `json:"bidId"`
+	BidVersion          int       `json:"bidVersion"`
+	BuyerOrganizationId string    `json:"buyerOrganizationId"`
+	SupplierType        string    `json:"supplierType"`
+	SupplierId          string    `json:"supplierId"`
+	Amount              *float64  `json:"amount,omitempty"`
+	Status              string    `json:"status"`
+	Version             int       `json:"version"`
+	CreatedAt           time.Time `json:"createdAt"`
+	UpdatedAt           time.Time `json:"updatedAt"`
+}
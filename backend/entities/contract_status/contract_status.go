@@ -0,0 +1,8 @@
+package contract_status
+
+const (
+	DRAFT      string = "Draft"
+	SIGNED     string = "Signed"
+	COMPLETED  string = "Completed"
+	TERMINATED string = "Terminated"
+)
@@ -10,6 +10,7 @@ type Employee struct {
 	Username  string         `json:"username"`
 	FirstName sql.NullString `json:"firstName"`
 	LastName  sql.NullString `json:"lastName"`
+	Email     *string        `json:"email,omitempty"`
 	CreatedAt time.Time      `json:"createdAt"`
 	UpdatedAt time.Time      `json:"updatedAt"`
 }
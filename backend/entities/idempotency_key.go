@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// IdempotencyKey is a request reserved under a client key. StatusCode is nil
+// while the first request with the key is still running.
+type IdempotencyKey struct {
+	Key         string
+	Fingerprint string
+	StatusCode  *int
+	ContentType *string
+	Response    []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
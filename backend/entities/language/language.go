@@ -0,0 +1,6 @@
+package language
+
+const (
+	RU string = "ru"
+	EN string = "en"
+)
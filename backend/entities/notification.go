@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+type Notification struct {
+	Id        string     `json:"id"`
+	UserId    string     `json:"userId"`
+	Kind      string     `json:"kind"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	TenderId  *string    `json:"tenderId,omitempty"`
+	BidId     *string    `json:"bidId,omitempty"`
+	ReadAt    *time.Time `json:"readAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type NotificationPreference struct {
+	UserId       string    `json:"userId"`
+	Language     string    `json:"language"`
+	EmailEnabled bool      `json:"emailEnabled"`
+	InAppEnabled bool      `json:"inAppEnabled"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
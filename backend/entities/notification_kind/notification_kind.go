@@ -0,0 +1,7 @@
+package notification_kind
+
+const (
+	BID_CREATED          string = "BidCreated"
+	DECISION_MADE        string = "DecisionMade"
+	DEADLINE_APPROACHING string = "DeadlineApproaching"
+)
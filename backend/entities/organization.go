@@ -6,10 +6,11 @@ import (
 )
 
 type Organization struct {
-	Id          string         `json:"id"`
-	Name        string         `json:"name"`
-	Description sql.NullString `json:"description"`
-	Type        string         `json:"type"`
-	CreatedAt   time.Time      `json:"createdAt"`
-	UpdatedAt   time.Time      `json:"updatedAt"`
+	Id             string         `json:"id"`
+	Name           string         `json:"name"`
+	Description    sql.NullString `json:"description"`
+	Type           string         `json:"type"`
+	ConflictPolicy string         `json:"conflictPolicy"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
 }
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+type Qualification struct {
+	Id             string     `json:"id"`
+	SupplierType   string     `json:"supplierType"`
+	SupplierId     string     `json:"supplierId"`
+	OrganizationId string     `json:"organizationId"`
+	ServiceType    string     `json:"serviceType"`
+	Description    string     `json:"description"`
+	Status         string     `json:"status"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+	ReviewedBy     *string    `json:"reviewedBy,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
@@ -0,0 +1,7 @@
+package qualification_status
+
+const (
+	PENDING  string = "Pending"
+	APPROVED string = "Approved"
+	REJECTED string = "Rejected"
+)
@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+type Status struct {
+	Status    string    `json:"status"`
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type DecisionVote struct {
+	UserId             *string   `json:"userId,omitempty"`
+	Decision           string    `json:"decision"`
+	ConflictOfInterest bool      `json:"conflictOfInterest"`
+	CreatedAt          time.Time `json:"createdAt"`
+}
+
+type Decision struct {
+	Status    string         `json:"status"`
+	Version   int            `json:"version"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	Votes     []DecisionVote `json:"votes"`
+}
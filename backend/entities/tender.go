@@ -3,13 +3,22 @@ package entities
 import "time"
 
 type Tender struct {
-	Id             string    `json:"id"`
-	Name           string    `json:"name"`
-	Description    string    `json:"description"`
-	Status         string    `json:"status"`
-	ServiceType    []string  `json:"serviceType"`
-	Version        int       `json:"version"`
-	CreatedAt      time.Time `json:"createdAt"`
-	UpdatedAt      time.Time `json:"updatedAt"`
-	OrganizationId string    `json:"organizationId"`
+	Id                   string     `json:"id"`
+	Name                 string     `json:"name"`
+	Description          string     `json:"description"`
+	Status               string     `json:"status"`
+	ServiceType          []string   `json:"serviceType"`
+	Version              int        `json:"version"`
+	CreatedAt            time.Time  `json:"createdAt"`
+	UpdatedAt            time.Time  `json:"updatedAt"`
+	OrganizationId       string     `json:"organizationId"`
+	Deadline             *time.Time `json:"deadline,omitempty"`
+	RequireQualification bool       `json:"requireQualification"`
+	Budget               *float64   `json:"budget,omitempty"`
+	Currency             *string    `json:"currency,omitempty"`
+	BudgetPublic         bool       `json:"budgetPublic"`
+	RejectOverBudget     bool       `json:"rejectOverBudget"`
+	AuctionStartsAt      *time.Time `json:"auctionStartsAt,omitempty"`
+	AuctionEndsAt        *time.Time `json:"auctionEndsAt,omitempty"`
+	AuctionExtension     int        `json:"auctionExtensionSeconds,omitempty"`
 }
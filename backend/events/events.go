@@ -0,0 +1,46 @@
+package events
+
+import (
+	"backend/entities"
+	"time"
+)
+
+const (
+	TENDER_CREATED   string = "tender.created"
+	TENDER_UPDATED   string = "tender.updated"
+	BID_CREATED      string = "bid.created"
+	BID_UPDATED      string = "bid.updated"
+	DECISION_CREATED string = "decision.created"
+)
+
+type Event struct {
+	Id             uint64           `json:"id"`
+	Type           string           `json:"type"`
+	TenderId       string           `json:"tenderId"`
+	OrganizationId string           `json:"organizationId"`
+	TenderStatus   string           `json:"tenderStatus"`
+	Tender         *entities.Tender `json:"tender,omitempty"`
+	Bid            *entities.Bid    `json:"bid,omitempty"`
+	Decision       string           `json:"decision,omitempty"`
+	CreatedAt      time.Time        `json:"createdAt"`
+}
+
+func TenderEvent(eventType string, tender entities.Tender) Event {
+	return Event{
+		Type:           eventType,
+		TenderId:       tender.Id,
+		OrganizationId: tender.OrganizationId,
+		TenderStatus:   tender.Status,
+		Tender:         &tender,
+	}
+}
+
+func BidEvent(eventType string, tender entities.Tender, bid entities.Bid) Event {
+	return Event{
+		Type:           eventType,
+		TenderId:       tender.Id,
+		OrganizationId: tender.OrganizationId,
+		TenderStatus:   tender.Status,
+		Bid:            &bid,
+	}
+}
@@ -0,0 +1,96 @@
+package events
+
+import (
+	"backend/config"
+	"sync"
+	"time"
+)
+
+type Subscription struct {
+	C chan Event
+}
+
+type Hub struct {
+	mu          sync.Mutex
+	lastId      uint64
+	history     []Event
+	historySize int
+	bufferSize  int
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+func NewHub(cfg *config.Config) *Hub {
+	eventsConfig := cfg.GetEventsConfig()
+	return &Hub{
+		history:     make([]Event, 0, eventsConfig.GetHistorySize()),
+		historySize: eventsConfig.GetHistorySize(),
+		bufferSize:  eventsConfig.GetBufferSize(),
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+func (h *Hub) Publish(event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.lastId++
+	event.Id = h.lastId
+	event.CreatedAt = time.Now().UTC()
+	if h.historySize > 0 {
+		if len(h.history) == h.historySize {
+			h.history = h.history[1:]
+		}
+		h.history = append(h.history, event)
+	}
+	for sub := range h.subscribers {
+		select {
+		case sub.C <- event:
+		default:
+			delete(h.subscribers, sub)
+			close(sub.C)
+		}
+	}
+}
+
+func (h *Hub) Subscribe(lastEventId uint64) (*Subscription, []Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	sub := &Subscription{C: make(chan Event, h.bufferSize)}
+	if h.closed {
+		close(sub.C)
+		return sub, nil
+	}
+	h.subscribers[sub] = struct{}{}
+	if lastEventId == 0 {
+		return sub, nil
+	}
+	missed := make([]Event, 0)
+	for _, event := range h.history {
+		if event.Id > lastEventId {
+			missed = append(missed, event)
+		}
+	}
+	return sub, missed
+}
+
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.C)
+	}
+}
+
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for sub := range h.subscribers {
+		delete(h.subscribers, sub)
+		close(sub.C)
+	}
+}
@@ -0,0 +1,94 @@
+package grpcapi
+
+import (
+	"backend/grpcapi/pb"
+	"backend/service"
+	"context"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type bidServer struct {
+	pb.UnimplementedBidServiceServer
+	bids *service.BidService
+}
+
+func (b bidServer) CreateBid(ctx context.Context, request *pb.CreateBidRequest) (*pb.Bid, error) {
+	bid, err := b.bids.Create(ctx, request.Username, service.CreateBidParams{
+		Name:        request.Name,
+		Description: request.Description,
+		TenderId:    request.TenderId,
+		AuthorType:  request.AuthorType,
+		AuthorId:    request.AuthorId,
+		Amount:      request.Amount,
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toBid(bid), nil
+}
+
+func (b bidServer) ListMyBids(ctx context.Context, request *pb.ListMyBidsRequest) (*pb.ListBidsResponse, error) {
+	bids, err := b.bids.FilterMy(ctx, service.FilterBidsParams{
+		Limit:    pageOrDefault(request.Limit),
+		Offset:   int(request.Offset),
+		Username: request.Username,
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toBids(bids), nil
+}
+
+func (b bidServer) ListTenderBids(ctx context.Context, request *pb.ListTenderBidsRequest) (*pb.ListBidsResponse, error) {
+	bids, err := b.bids.FilterByTender(ctx, request.TenderId, service.FilterBidsByTenderParams{
+		Limit:            pageOrDefault(request.Limit),
+		Offset:           int(request.Offset),
+		Username:         request.Username,
+		IncludeWithdrawn: request.IncludeWithdrawn,
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toBids(bids), nil
+}
+
+func (b bidServer) GetBidStatus(ctx context.Context, request *pb.GetBidStatusRequest) (*pb.BidStatus, error) {
+	status, err := b.bids.GetStatus(ctx, request.BidId, request.Username)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &pb.BidStatus{
+		Status:    status.Status,
+		Version:   int32(status.Version),
+		UpdatedAt: timestamppb.New(status.UpdatedAt),
+	}, nil
+}
+
+func (b bidServer) UpdateBidStatus(ctx context.Context, request *pb.UpdateBidStatusRequest) (*pb.Bid, error) {
+	bid, err := b.bids.ChangeStatus(ctx, request.BidId, request.Username, request.Status)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toBid(bid), nil
+}
+
+func (b bidServer) EditBid(ctx context.Context, request *pb.EditBidRequest) (*pb.Bid, error) {
+	bid, err := b.bids.Edit(ctx, request.BidId, request.Username, service.EditBidParams{
+		Name:        request.GetName(),
+		Description: request.GetDescription(),
+		Status:      request.GetStatus(),
+		Amount:      request.Amount,
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toBid(bid), nil
+}
+
+func (b bidServer) WithdrawBid(ctx context.Context, request *pb.WithdrawBidRequest) (*pb.Bid, error) {
+	bid, err := b.bids.Withdraw(ctx, request.BidId, request.Username, request.Reason)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toBid(bid), nil
+}
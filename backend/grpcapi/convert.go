@@ -0,0 +1,113 @@
+package grpcapi
+
+import (
+	"backend/entities"
+	"backend/grpcapi/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromTimestamp(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+	value := t.AsTime()
+	return &value
+}
+
+func pageOrDefault(limit int32) int {
+	if limit == 0 {
+		return 5
+	}
+	return int(limit)
+}
+
+func toTender(tender entities.Tender) *pb.Tender {
+	return &pb.Tender{
+		Id:                      tender.Id,
+		Name:                    tender.Name,
+		Description:             tender.Description,
+		Status:                  tender.Status,
+		ServiceType:             tender.ServiceType,
+		Version:                 int32(tender.Version),
+		CreatedAt:               timestamppb.New(tender.CreatedAt),
+		UpdatedAt:               timestamppb.New(tender.UpdatedAt),
+		OrganizationId:          tender.OrganizationId,
+		Deadline:                toTimestamp(tender.Deadline),
+		RequireQualification:    tender.RequireQualification,
+		Budget:                  tender.Budget,
+		Currency:                tender.Currency,
+		BudgetPublic:            tender.BudgetPublic,
+		RejectOverBudget:        tender.RejectOverBudget,
+		AuctionStartsAt:         toTimestamp(tender.AuctionStartsAt),
+		AuctionEndsAt:           toTimestamp(tender.AuctionEndsAt),
+		AuctionExtensionSeconds: int32(tender.AuctionExtension),
+	}
+}
+
+func toTenders(tenders []entities.Tender) *pb.ListTendersResponse {
+	response := &pb.ListTendersResponse{Tenders: make([]*pb.Tender, 0, len(tenders))}
+	for _, tender := range tenders {
+		response.Tenders = append(response.Tenders, toTender(tender))
+	}
+	return response
+}
+
+func toBid(bid entities.Bid) *pb.Bid {
+	result := &pb.Bid{
+		Id:                 bid.Id,
+		TenderId:           bid.TenderId,
+		Name:               bid.Name,
+		Description:        bid.Description,
+		Status:             bid.Status,
+		AuthorType:         bid.AuthorType,
+		AuthorId:           bid.AuthorId,
+		Version:            int32(bid.Version),
+		CreatedAt:          timestamppb.New(bid.CreatedAt),
+		UpdatedAt:          timestamppb.New(bid.UpdatedAt),
+		WithdrawalReason:   bid.WithdrawalReason,
+		WithdrawnBy:        bid.WithdrawnBy,
+		WithdrawnAt:        toTimestamp(bid.WithdrawnAt),
+		ConflictOfInterest: bid.ConflictOfInterest,
+		Amount:             bid.Amount,
+		OverBudget:         bid.OverBudget,
+	}
+	if bid.AuctionRank != nil {
+		rank := int32(*bid.AuctionRank)
+		result.AuctionRank = &rank
+	}
+	return result
+}
+
+func toBids(bids []entities.Bid) *pb.ListBidsResponse {
+	response := &pb.ListBidsResponse{Bids: make([]*pb.Bid, 0, len(bids))}
+	for _, bid := range bids {
+		response.Bids = append(response.Bids, toBid(bid))
+	}
+	return response
+}
+
+func toDecision(decision entities.Decision) *pb.Decision {
+	result := &pb.Decision{
+		Decision:  decision.Status,
+		Version:   int32(decision.Version),
+		UpdatedAt: timestamppb.New(decision.UpdatedAt),
+		Votes:     make([]*pb.DecisionVote, 0, len(decision.Votes)),
+	}
+	for _, vote := range decision.Votes {
+		result.Votes = append(result.Votes, &pb.DecisionVote{
+			UserId:             vote.UserId,
+			Decision:           vote.Decision,
+			ConflictOfInterest: vote.ConflictOfInterest,
+			CreatedAt:          timestamppb.New(vote.CreatedAt),
+		})
+	}
+	return result
+}
@@ -0,0 +1,28 @@
+package grpcapi
+
+import (
+	"backend/grpcapi/pb"
+	"backend/service"
+	"context"
+)
+
+type decisionServer struct {
+	pb.UnimplementedDecisionServiceServer
+	bids *service.BidService
+}
+
+func (d decisionServer) SubmitDecision(ctx context.Context, request *pb.SubmitDecisionRequest) (*pb.Tender, error) {
+	tender, err := d.bids.SetDecision(ctx, request.BidId, request.Username, request.Decision)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toTender(tender), nil
+}
+
+func (d decisionServer) GetDecision(ctx context.Context, request *pb.GetDecisionRequest) (*pb.Decision, error) {
+	decision, err := d.bids.GetDecision(ctx, request.BidId, request.Username)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toDecision(decision), nil
+}
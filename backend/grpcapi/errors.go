@@ -0,0 +1,42 @@
+package grpcapi
+
+import (
+	"backend/entities/language"
+	"backend/service"
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+)
+
+func toStatus(ctx context.Context, err error) error {
+	serviceErr, ok := service.AsError(err)
+	if !ok {
+		slog.ErrorContext(ctx, "internal error", "component", "grpc", "error", err)
+		serviceErr = &service.Error{Code: service.CodeInternal}
+	}
+	code := codes.Internal
+	switch serviceErr.Code.Kind {
+	case service.ErrInvalid:
+		code = codes.InvalidArgument
+	case service.ErrUnauthorized:
+		code = codes.Unauthenticated
+	case service.ErrForbidden:
+		code = codes.PermissionDenied
+	case service.ErrNotFound:
+		code = codes.NotFound
+	case service.ErrConflict:
+		code = codes.FailedPrecondition
+	case service.ErrTimeout:
+		code = codes.DeadlineExceeded
+	case service.ErrUnavailable:
+		code = codes.Unavailable
+	case service.ErrRateLimited:
+		code = codes.ResourceExhausted
+	}
+	message := serviceErr.Code.Name + ": " + serviceErr.Code.Message(language.EN)
+	if len(serviceErr.Detail) > 0 {
+		message += ": " + serviceErr.Detail
+	}
+	return status.Error(code, message)
+}
@@ -0,0 +1,108 @@
+package grpcapi
+
+import (
+	"backend/idempotency"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"path"
+	"strings"
+)
+
+const (
+	idempotencyKeyHeader     = "idempotency-key"
+	idempotentReplayedHeader = "idempotent-replayed"
+	idempotentResponsePrefix = "application/x-protobuf; message="
+)
+
+var idempotentMethods = map[string]bool{
+	"CreateTender":   true,
+	"CreateBid":      true,
+	"SubmitDecision": true,
+}
+
+func protoFingerprint(method string, request proto.Message) (string, error) {
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	hash.Write([]byte(method + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func replayResponse(contentType *string, response []byte) (proto.Message, error) {
+	if contentType == nil || !strings.HasPrefix(*contentType, idempotentResponsePrefix) {
+		return nil, errors.New("stored response is not a protobuf message")
+	}
+	name := protoreflect.FullName(strings.TrimPrefix(*contentType, idempotentResponsePrefix))
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(name)
+	if err != nil {
+		return nil, err
+	}
+	message := messageType.New().Interface()
+	return message, proto.Unmarshal(response, message)
+}
+
+// idempotencyInterceptor makes the create methods idempotent for calls with an
+// idempotency-key metadata entry, like the Idempotency-Key header of the HTTP
+// API. Unlike HTTP only successful responses are stored, a failed call
+// releases the key.
+func idempotencyInterceptor(st *idempotency.Store) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		message, ok := request.(proto.Message)
+		if !ok || !idempotentMethods[path.Base(info.FullMethod)] {
+			return handler(ctx, request)
+		}
+		var key string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			key = strings.Join(md.Get(idempotencyKeyHeader), "")
+		}
+		if len(key) == 0 {
+			return handler(ctx, request)
+		}
+		hash, err := protoFingerprint(info.FullMethod, message)
+		if err != nil {
+			return nil, toStatus(ctx, err)
+		}
+		record, err := st.Reserve(ctx, key, hash)
+		if err != nil {
+			return nil, toStatus(ctx, err)
+		}
+		if record != nil {
+			response, err := replayResponse(record.ContentType, record.Response)
+			if err != nil {
+				return nil, toStatus(ctx, err)
+			}
+			_ = grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedHeader, "true"))
+			return response, nil
+		}
+		response, err := handler(ctx, request)
+		storeCtx := context.WithoutCancel(ctx)
+		if err != nil {
+			st.Release(storeCtx, key)
+			return nil, err
+		}
+		responseMessage, ok := response.(proto.Message)
+		if !ok {
+			st.Release(storeCtx, key)
+			return response, nil
+		}
+		body, err := proto.Marshal(responseMessage)
+		if err != nil {
+			st.Release(storeCtx, key)
+			return response, nil
+		}
+		contentType := idempotentResponsePrefix + string(responseMessage.ProtoReflect().Descriptor().FullName())
+		st.Save(storeCtx, key, int(codes.OK), contentType, body)
+		return response, nil
+	}
+}
@@ -0,0 +1,48 @@
+package grpcapi
+
+import (
+	"backend/logging"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const requestIdHeader = "x-request-id"
+
+func loggingInterceptor(
+	ctx context.Context,
+	request any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+	var requestId string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		requestId = strings.Join(md.Get(requestIdHeader), "")
+	}
+	requestId = logging.RequestId(requestId)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIdHeader, requestId))
+	ctx = logging.WithFields(ctx)
+	logging.Set(ctx, "request_id", requestId)
+	response, err := handler(ctx, request)
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK:
+	case codes.Internal, codes.Unknown, codes.Unavailable, codes.DeadlineExceeded:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+	slog.Log(ctx, level, "rpc",
+		"method", info.FullMethod,
+		"code", code.String(),
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+	return response, err
+}
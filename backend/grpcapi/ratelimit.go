@@ -0,0 +1,45 @@
+package grpcapi
+
+import (
+	"backend/ratelimit"
+	"backend/service"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"math"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+)
+
+type usernameRequest interface {
+	GetUsername() string
+}
+
+// rateLimitInterceptor applies the same budgets as the HTTP API. Get and List
+// methods are reads, the rest are writes.
+func rateLimitInterceptor(l *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !l.IsEnabled() {
+			return handler(ctx, request)
+		}
+		method := path.Base(info.FullMethod)
+		write := !strings.HasPrefix(method, "Get") && !strings.HasPrefix(method, "List")
+		var ip, username string
+		if p, ok := peer.FromContext(ctx); ok {
+			ip, _, _ = net.SplitHostPort(p.Addr.String())
+		}
+		if r, ok := request.(usernameRequest); ok {
+			username = r.GetUsername()
+		}
+		result := l.Allow(ctx, write, ip, username)
+		if !result.Allowed {
+			retryAfter := strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds())))
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", retryAfter))
+			return nil, toStatus(ctx, service.NewError(service.CodeRateLimited, ""))
+		}
+		return handler(ctx, request)
+	}
+}
@@ -0,0 +1,34 @@
+package grpcapi
+
+import (
+	"backend/config"
+	"backend/grpcapi/pb"
+	"backend/idempotency"
+	"backend/ratelimit"
+	"backend/service"
+	"backend/tracing"
+	"google.golang.org/grpc"
+)
+
+func NewServer(
+	tenders *service.TenderService,
+	bids *service.BidService,
+	t *tracing.Tracing,
+	l *ratelimit.Limiter,
+	idem *idempotency.Store,
+	cfg *config.Config,
+) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.StatsHandler(t.GRPCHandler()),
+		grpc.ChainUnaryInterceptor(
+			loggingInterceptor,
+			timeoutInterceptor(cfg.GetHTTPConfig().GetRequestTimeout()),
+			rateLimitInterceptor(l),
+			idempotencyInterceptor(idem),
+		),
+	)
+	pb.RegisterTenderServiceServer(server, tenderServer{tenders: tenders})
+	pb.RegisterBidServiceServer(server, bidServer{bids: bids})
+	pb.RegisterDecisionServiceServer(server, decisionServer{bids: bids})
+	return server
+}
@@ -0,0 +1,105 @@
+package grpcapi
+
+import (
+	"backend/grpcapi/pb"
+	"backend/service"
+	"context"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type tenderServer struct {
+	pb.UnimplementedTenderServiceServer
+	tenders *service.TenderService
+}
+
+func (t tenderServer) CreateTender(ctx context.Context, request *pb.CreateTenderRequest) (*pb.Tender, error) {
+	tender, err := t.tenders.Create(ctx, service.CreateTenderParams{
+		Name:                 request.Name,
+		Description:          request.Description,
+		ServiceType:          request.ServiceType,
+		OrganizationId:       request.OrganizationId,
+		Deadline:             fromTimestamp(request.Deadline),
+		RequireQualification: request.RequireQualification,
+		Budget:               request.Budget,
+		Currency:             request.Currency,
+		BudgetPublic:         request.BudgetPublic,
+		RejectOverBudget:     request.RejectOverBudget,
+		AuctionStartsAt:      fromTimestamp(request.AuctionStartsAt),
+		AuctionEndsAt:        fromTimestamp(request.AuctionEndsAt),
+		AuctionExtension:     int(request.AuctionExtensionSeconds),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toTender(tender), nil
+}
+
+func (t tenderServer) ListTenders(ctx context.Context, request *pb.ListTendersRequest) (*pb.ListTendersResponse, error) {
+	tenders, err := t.tenders.Filter(ctx, service.FilterTendersParams{
+		Limit:       pageOrDefault(request.Limit),
+		Offset:      int(request.Offset),
+		ServiceType: request.ServiceType,
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toTenders(tenders), nil
+}
+
+func (t tenderServer) ListMyTenders(ctx context.Context, request *pb.ListMyTendersRequest) (*pb.ListTendersResponse, error) {
+	tenders, err := t.tenders.FilterMy(ctx, service.FilterMyTendersParams{
+		Limit:    pageOrDefault(request.Limit),
+		Offset:   int(request.Offset),
+		Username: request.Username,
+	})
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toTenders(tenders), nil
+}
+
+func (t tenderServer) GetTenderStatus(ctx context.Context, request *pb.GetTenderStatusRequest) (*pb.TenderStatus, error) {
+	status, err := t.tenders.GetStatus(ctx, request.TenderId, request.Username)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &pb.TenderStatus{
+		Status:    status.Status,
+		Version:   int32(status.Version),
+		UpdatedAt: timestamppb.New(status.UpdatedAt),
+	}, nil
+}
+
+func (t tenderServer) UpdateTenderStatus(ctx context.Context, request *pb.UpdateTenderStatusRequest) (*pb.Tender, error) {
+	tender, err := t.tenders.UpdateStatus(ctx, request.TenderId, request.Username, request.Status)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toTender(tender), nil
+}
+
+func (t tenderServer) EditTender(ctx context.Context, request *pb.EditTenderRequest) (*pb.Tender, error) {
+	params := service.EditTenderParams{
+		Name:                 request.GetName(),
+		Description:          request.GetDescription(),
+		ServiceType:          request.ServiceType,
+		Status:               request.GetStatus(),
+		Deadline:             fromTimestamp(request.Deadline),
+		RequireQualification: request.RequireQualification,
+		Budget:               request.Budget,
+		Currency:             request.Currency,
+		BudgetPublic:         request.BudgetPublic,
+		RejectOverBudget:     request.RejectOverBudget,
+		AuctionStartsAt:      fromTimestamp(request.AuctionStartsAt),
+		AuctionEndsAt:        fromTimestamp(request.AuctionEndsAt),
+	}
+	if request.AuctionExtensionSeconds != nil {
+		extension := int(*request.AuctionExtensionSeconds)
+		params.AuctionExtension = &extension
+	}
+	tender, err := t.tenders.Edit(ctx, request.TenderId, request.Username, params)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return toTender(tender), nil
+}
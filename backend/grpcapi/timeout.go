@@ -0,0 +1,20 @@
+package grpcapi
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"time"
+)
+
+// timeoutInterceptor bounds every call by the same request timeout as the HTTP
+// API. A shorter deadline set by the client is kept.
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if timeout <= 0 {
+			return handler(ctx, request)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return handler(ctx, request)
+	}
+}
@@ -0,0 +1,11 @@
+package handlers
+
+import "github.com/gofiber/fiber/v2"
+
+func (h Handlers) GetAuction(c *fiber.Ctx) error {
+	result, err := h.tenders.GetAuction(c.UserContext(), c.Params("tenderId"), c.Query("username"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(result)
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"backend/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h Handlers) CreateBlocklistEntry(c *fiber.Ctx) error {
+	var request service.CreateBlocklistEntryParams
+	if err := c.BodyParser(&request); err != nil {
+		return service.NewError(service.CodeInvalidBody, err.Error())
+	}
+	entry, err := h.organizations.CreateBlocklistEntry(c.UserContext(), c.Params("organizationId"), c.Query("username"), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(entry)
+}
+
+func (h Handlers) GetBlocklist(c *fiber.Ctx) error {
+	var request service.GetBlocklistParams
+	if err := c.QueryParser(&request); err != nil {
+		return service.NewError(service.CodeInvalidQuery, err.Error())
+	}
+	request.Limit = c.QueryInt("limit", 5)
+	request.Offset = c.QueryInt("offset", 0)
+	entries, err := h.organizations.GetBlocklist(c.UserContext(), c.Params("organizationId"), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(entries)
+}
+
+func (h Handlers) DeleteBlocklistEntry(c *fiber.Ctx) error {
+	entry, err := h.organizations.DeleteBlocklistEntry(c.UserContext(), c.Params("organizationId"), c.Params("entryId"), c.Query("username"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(entry)
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"backend/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h Handlers) GetMyContracts(c *fiber.Ctx) error {
+	var request service.FilterContractsParams
+	if err := c.QueryParser(&request); err != nil {
+		return service.NewError(service.CodeInvalidQuery, err.Error())
+	}
+	request.Limit = c.QueryInt("limit", 5)
+	request.Offset = c.QueryInt("offset", 0)
+	contracts, err := h.contracts.FilterMy(c.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(contracts)
+}
+
+func (h Handlers) GetContract(c *fiber.Ctx) error {
+	contract, err := h.contracts.Get(c.UserContext(), c.Params("contractId"), c.Query("username"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(contract)
+}
+
+func (h Handlers) ChangeContractStatus(c *fiber.Ctx) error {
+	contract, err := h.contracts.ChangeStatus(c.UserContext(), c.Params("contractId"), c.Query("username"), c.Query("status"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(contract)
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"backend/entities/language"
+	"backend/service"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"log/slog"
+	"strconv"
+)
+
+type problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance"`
+	Code     string `json:"code"`
+}
+
+func toServiceError(c *fiber.Ctx, err error) *service.Error {
+	if serviceErr, ok := service.AsError(err); ok {
+		return serviceErr
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		if fiberErr.Code == fiber.StatusNotFound {
+			return &service.Error{Code: service.CodeRouteNotFound}
+		}
+		if fiberErr.Code < fiber.StatusInternalServerError {
+			code := &service.Code{
+				Name:     "HTTP_" + strconv.Itoa(fiberErr.Code),
+				Kind:     service.ErrInvalid,
+				Status:   fiberErr.Code,
+				Messages: map[string]string{language.EN: fiberErr.Message},
+			}
+			return &service.Error{Code: code}
+		}
+	}
+	slog.ErrorContext(c.UserContext(), "internal error", "method", c.Method(), "path", c.OriginalURL(), "error", err)
+	return &service.Error{Code: service.CodeInternal}
+}
+
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	serviceErr := toServiceError(c, err)
+	lang := c.AcceptsLanguages(language.EN, language.RU)
+	return c.Status(serviceErr.Code.Status).JSON(problem{
+		Type:     "urn:tenders:error:" + serviceErr.Code.Name,
+		Title:    serviceErr.Code.Message(lang),
+		Status:   serviceErr.Code.Status,
+		Detail:   serviceErr.Detail,
+		Instance: c.Path(),
+		Code:     serviceErr.Code.Name,
+	}, "application/problem+json")
+}
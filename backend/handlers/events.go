@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"backend/entities/author_type"
+	"backend/entities/tender_status"
+	"backend/events"
+	"backend/service"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+	"time"
+)
+
+type subscribeRequest struct {
+	Username       string `json:"username" validate:"required,max=50"`
+	TenderId       string `json:"tenderId" validate:"omitempty,uid"`
+	OrganizationId string `json:"organizationId" validate:"omitempty,uid"`
+	MyBids         bool   `json:"myBids"`
+	LastEventId    uint64 `json:"lastEventId"`
+}
+
+type eventsFilter struct {
+	userId         string
+	organizations  map[string]bool
+	tenderId       string
+	organizationId string
+	myBids         bool
+}
+
+func (f eventsFilter) matches(event events.Event) bool {
+	if len(f.tenderId) > 0 && event.TenderId != f.tenderId {
+		return false
+	}
+	if len(f.organizationId) > 0 && event.OrganizationId != f.organizationId {
+		return false
+	}
+	isOwner := f.organizations[event.OrganizationId]
+	if event.Bid == nil {
+		return !f.myBids && (isOwner || event.TenderStatus == tender_status.PUBLISHED)
+	}
+	isAuthor := event.Bid.AuthorId == f.userId
+	if event.Bid.AuthorType == author_type.ORGANIZATION {
+		isAuthor = f.organizations[event.Bid.AuthorId]
+	}
+	if f.myBids {
+		return isAuthor
+	}
+	return isOwner || isAuthor
+}
+
+// redact hides a closed budget from subscribers outside the tender's
+// organization. The event is shared between subscribers, so the tender is copied.
+func (f eventsFilter) redact(event events.Event) events.Event {
+	if event.Tender == nil || event.Tender.BudgetPublic || f.organizations[event.OrganizationId] {
+		return event
+	}
+	tender := *event.Tender
+	tender.Budget = nil
+	tender.Currency = nil
+	event.Tender = &tender
+	return event
+}
+
+func writeEvent(w *bufio.Writer, event events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func (h Handlers) SubscribeEvents(c *fiber.Ctx) error {
+	var request subscribeRequest
+	if err := c.QueryParser(&request); err != nil {
+		return service.NewError(service.CodeInvalidQuery, err.Error())
+	}
+	if err := h.validator.Struct(request); err != nil {
+		return service.NewError(service.CodeInvalidParams, err.Error())
+	}
+	if lastEventId := c.Get("Last-Event-ID"); len(lastEventId) > 0 {
+		id, err := strconv.ParseUint(lastEventId, 10, 64)
+		if err != nil {
+			return service.NewError(service.CodeInvalidParams, "Last-Event-ID: "+err.Error())
+		}
+		request.LastEventId = id
+	}
+	userId, organizationIds, err := h.organizations.Memberships(c.UserContext(), request.Username)
+	if err != nil {
+		return err
+	}
+	filter := eventsFilter{
+		userId:         userId,
+		organizations:  make(map[string]bool, len(organizationIds)),
+		tenderId:       request.TenderId,
+		organizationId: request.OrganizationId,
+		myBids:         request.MyBids,
+	}
+	for _, organizationId := range organizationIds {
+		filter.organizations[organizationId] = true
+	}
+
+	sub, missed := h.hub.Subscribe(request.LastEventId)
+	heartbeat := h.heartbeat
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer h.hub.Unsubscribe(sub)
+		for _, event := range missed {
+			if filter.matches(event) {
+				if err := writeEvent(w, filter.redact(event)); err != nil {
+					return
+				}
+			}
+		}
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case event, ok := <-sub.C:
+				if !ok {
+					return
+				}
+				if !filter.matches(event) {
+					continue
+				}
+				if err := writeEvent(w, filter.redact(event)); err != nil {
+					return
+				}
+			case <-ticker.C:
+				if _, err := w.WriteString(": ping\n\n"); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	})
+	return nil
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"backend/service"
+	"backend/tenderimport"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ImportTenders creates tenders from a CSV or XLSX file sent as the "file"
+// form field. With dryRun=true it only reports row errors.
+func (h Handlers) ImportTenders(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return service.NewError(service.CodeInvalidBody, "Expected a CSV or XLSX file in the \"file\" form field")
+	}
+	format, err := tenderimport.Format(fileHeader.Filename)
+	if err != nil {
+		return service.NewError(service.CodeInvalidBody, err.Error())
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	rows, err := tenderimport.Parse(file, format)
+	if err != nil {
+		return service.NewError(service.CodeInvalidBody, err.Error())
+	}
+	result, err := h.tenders.Import(c.UserContext(), rows, c.QueryBool("dryRun"))
+	if err != nil {
+		return err
+	}
+	if !result.Valid && !result.DryRun {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(result)
+	}
+	return c.Status(fiber.StatusOK).JSON(result)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"backend/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h Handlers) GetNotifications(c *fiber.Ctx) error {
+	var request service.FilterNotificationsParams
+	if err := c.QueryParser(&request); err != nil {
+		return service.NewError(service.CodeInvalidQuery, err.Error())
+	}
+	request.Limit = c.QueryInt("limit", 5)
+	request.Offset = c.QueryInt("offset", 0)
+	notifications, err := h.notifications.Filter(c.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(notifications)
+}
+
+func (h Handlers) MarkNotificationRead(c *fiber.Ctx) error {
+	if err := h.notifications.MarkRead(c.UserContext(), c.Params("notificationId"), c.Query("username")); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).SendString("ok")
+}
+
+func (h Handlers) GetNotificationPreference(c *fiber.Ctx) error {
+	preference, err := h.notifications.GetPreference(c.UserContext(), c.Query("username"))
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(preference)
+}
+
+func (h Handlers) SetNotificationPreference(c *fiber.Ctx) error {
+	var request service.SetNotificationPreferenceParams
+	if err := c.BodyParser(&request); err != nil {
+		return service.NewError(service.CodeInvalidBody, err.Error())
+	}
+	preference, err := h.notifications.SetPreference(c.UserContext(), c.Query("username"), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(preference)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"backend/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h Handlers) CreateQualification(c *fiber.Ctx) error {
+	var request service.CreateQualificationParams
+	if err := c.BodyParser(&request); err != nil {
+		return service.NewError(service.CodeInvalidBody, err.Error())
+	}
+	qualification, err := h.qualifications.Create(c.UserContext(), c.Query("username"), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(qualification)
+}
+
+func (h Handlers) GetMyQualifications(c *fiber.Ctx) error {
+	var request service.FilterQualificationsParams
+	if err := c.QueryParser(&request); err != nil {
+		return service.NewError(service.CodeInvalidQuery, err.Error())
+	}
+	request.Limit = c.QueryInt("limit", 5)
+	request.Offset = c.QueryInt("offset", 0)
+	qualifications, err := h.qualifications.FilterMy(c.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(qualifications)
+}
+
+func (h Handlers) GetQualificationsToReview(c *fiber.Ctx) error {
+	var request service.FilterQualificationsParams
+	if err := c.QueryParser(&request); err != nil {
+		return service.NewError(service.CodeInvalidQuery, err.Error())
+	}
+	request.Limit = c.QueryInt("limit", 5)
+	request.Offset = c.QueryInt("offset", 0)
+	qualifications, err := h.qualifications.FilterToReview(c.UserContext(), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(qualifications)
+}
+
+func (h Handlers) ReviewQualification(c *fiber.Ctx) error {
+	var request service.ReviewQualificationParams
+	if err := c.QueryParser(&request); err != nil {
+		return service.NewError(service.CodeInvalidQuery, err.Error())
+	}
+	qualification, err := h.qualifications.Review(c.UserContext(), c.Params("qualificationId"), request)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(qualification)
+}
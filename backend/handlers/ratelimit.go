@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"backend/ratelimit"
+	"backend/service"
+	"github.com/gofiber/fiber/v2"
+	"math"
+	"strconv"
+	"time"
+)
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// RateLimit limits requests per client IP and per username query parameter.
+// GET, HEAD and OPTIONS spend the read budget, everything else the write one.
+func RateLimit(l *ratelimit.Limiter) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		write := c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead && c.Method() != fiber.MethodOptions
+		result := l.Allow(c.UserContext(), write, c.IP(), c.Query("username"))
+		if result.Limit > 0 {
+			c.Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+			c.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			c.Set("X-RateLimit-Reset", ceilSeconds(result.Reset))
+		}
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, ceilSeconds(result.RetryAfter))
+			return service.NewError(service.CodeRateLimited, "")
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"backend/config"
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"sort"
+	"strings"
+	"time"
+)
+
+type routeTimeout struct {
+	method   string
+	segments []string
+	params   int
+	timeout  time.Duration
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func (r routeTimeout) matches(method string, segments []string) bool {
+	if r.method != method || len(r.segments) != len(segments) {
+		return false
+	}
+	for i, segment := range r.segments {
+		if !strings.HasPrefix(segment, ":") && segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func Timeout(cfg config.ConfigHTTP) fiber.Handler {
+	routes := make([]routeTimeout, 0, len(cfg.GetRouteTimeouts()))
+	for route, timeout := range cfg.GetRouteTimeouts() {
+		method, path, _ := strings.Cut(route, " ")
+		segments := splitPath(path)
+		params := 0
+		for _, segment := range segments {
+			if strings.HasPrefix(segment, ":") {
+				params++
+			}
+		}
+		routes = append(routes, routeTimeout{strings.ToUpper(method), segments, params, timeout})
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].params < routes[j].params
+	})
+	return func(c *fiber.Ctx) error {
+		timeout := cfg.GetRequestTimeout()
+		segments := splitPath(c.Path())
+		for _, route := range routes {
+			if route.matches(c.Method(), segments) {
+				timeout = route.timeout
+				break
+			}
+		}
+		if timeout <= 0 {
+			return c.Next()
+		}
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
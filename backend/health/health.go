@@ -0,0 +1,106 @@
+package health
+
+import (
+	"backend/auction"
+	"backend/config"
+	"backend/migrations"
+	"backend/notifications"
+	"backend/storage"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOk   = "ok"
+	StatusFail = "fail"
+)
+
+type Component struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status     string               `json:"status"`
+	Components map[string]Component `json:"components"`
+}
+
+type check struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+type Checker struct {
+	checks       []check
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+func NewChecker(
+	s *storage.Storage,
+	auctions *auction.Worker,
+	deadlines *notifications.DeadlineWorker,
+	cfg *config.Config,
+) *Checker {
+	return &Checker{
+		checks: []check{
+			{name: "database", fn: s.Ping},
+			{name: "migrations", fn: func(ctx context.Context) error { return checkMigrations(ctx, s) }},
+			{name: "auction_worker", fn: auctions.Check},
+			{name: "deadline_worker", fn: deadlines.Check},
+		},
+		timeout: cfg.GetHealthConfig().GetCheckTimeout(),
+	}
+}
+
+// SetShuttingDown makes the service report not ready from now on.
+func (c *Checker) SetShuttingDown() {
+	c.shuttingDown.Store(true)
+}
+
+// Ready runs all checks concurrently and reports whether the service can take
+// traffic.
+func (c *Checker) Ready(ctx context.Context) (Report, bool) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	report := Report{Status: StatusOk, Components: make(map[string]Component, len(c.checks)+1)}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, ch := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			component := Component{Status: StatusOk}
+			if err := ch.fn(ctx); err != nil {
+				component = Component{Status: StatusFail, Error: err.Error()}
+			}
+			mu.Lock()
+			report.Components[ch.name] = component
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if c.shuttingDown.Load() {
+		report.Components["shutdown"] = Component{Status: StatusFail, Error: "shutting down"}
+	}
+	for _, component := range report.Components {
+		if component.Status != StatusOk {
+			report.Status = StatusFail
+		}
+	}
+	return report, report.Status == StatusOk
+}
+
+func checkMigrations(ctx context.Context, s *storage.Storage) error {
+	version, err := s.GetMigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if latest := migrations.Latest(); version < latest {
+		return fmt.Errorf("database is at version %d, expected %d", version, latest)
+	}
+	return nil
+}
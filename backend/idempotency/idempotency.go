@@ -0,0 +1,184 @@
+package idempotency
+
+import (
+	"backend/config"
+	"backend/entities"
+	"backend/logging"
+	"backend/service"
+	"backend/storage"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/gofiber/fiber/v2"
+	"log/slog"
+	"time"
+)
+
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderReplayed = "Idempotent-Replayed"
+	maxKeyLength   = 255
+)
+
+// keyStore is the part of the storage that keeps idempotency keys.
+type keyStore interface {
+	ReserveIdempotencyKey(
+		ctx context.Context,
+		key string,
+		fingerprint string,
+		ttl time.Duration,
+		lockTimeout time.Duration,
+	) (entities.IdempotencyKey, bool, error)
+	SaveIdempotentResponse(ctx context.Context, key string, statusCode int, contentType string, response []byte) error
+	DeleteIdempotencyKey(ctx context.Context, key string) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Store remembers responses of requests sent with an Idempotency-Key header
+// and periodically forgets expired ones.
+type Store struct {
+	s           keyStore
+	ttl         time.Duration
+	lockTimeout time.Duration
+	interval    time.Duration
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+func NewStore(s *storage.Storage, cfg *config.Config) *Store {
+	idempotencyConfig := cfg.GetIdempotencyConfig()
+	return &Store{
+		s:           s,
+		ttl:         idempotencyConfig.GetTTL(),
+		lockTimeout: idempotencyConfig.GetLockTimeout(),
+		interval:    idempotencyConfig.GetCleanupInterval(),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// fingerprint covers everything the handlers read from a request: method,
+// path, query and body.
+func fingerprint(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method() + "\n" + c.Path() + "\n"))
+	hash.Write(c.Request().URI().QueryString())
+	hash.Write([]byte("\n"))
+	hash.Write(c.Body())
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Reserve claims key for a request with the given fingerprint. It returns the
+// stored response when the request has already been done and nil when the
+// caller has to run it. A key held by a different request gives
+// IDEMPOTENCY_KEY_MISMATCH, a key whose request is still running gives
+// IDEMPOTENCY_KEY_IN_USE.
+func (st *Store) Reserve(ctx context.Context, key string, hash string) (*entities.IdempotencyKey, error) {
+	if len(key) > maxKeyLength {
+		return nil, service.NewError(service.CodeInvalidParams, "Idempotency-Key must be at most 255 characters")
+	}
+	logging.Set(ctx, "idempotency_key", key)
+	record, reserved, err := st.s.ReserveIdempotencyKey(ctx, key, hash, st.ttl, st.lockTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return nil, nil
+	}
+	if record.Fingerprint != hash {
+		return nil, service.NewError(service.CodeIdempotencyKeyMismatch, "")
+	}
+	if record.StatusCode == nil {
+		return nil, service.NewError(service.CodeIdempotencyKeyInUse, "")
+	}
+	return &record, nil
+}
+
+// Save stores the response of a reserved request.
+func (st *Store) Save(ctx context.Context, key string, statusCode int, contentType string, response []byte) {
+	if err := st.s.SaveIdempotentResponse(ctx, key, statusCode, contentType, response); err != nil {
+		slog.ErrorContext(ctx, "can't store idempotent response", "component", "idempotency", "error", err)
+	}
+}
+
+// Release forgets a reserved request so that it can be retried.
+func (st *Store) Release(ctx context.Context, key string) {
+	if err := st.s.DeleteIdempotencyKey(ctx, key); err != nil {
+		slog.ErrorContext(ctx, "can't release idempotency key", "component", "idempotency", "error", err)
+	}
+}
+
+// Middleware makes the route idempotent for requests with an Idempotency-Key.
+// The first request runs and its response is stored, unless it failed with a
+// 5xx status. Repeats with the same request get the stored response, repeats
+// with a different one get 422, repeats while the first one is running get 409.
+func (st *Store) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderKey)
+		if len(key) == 0 {
+			return c.Next()
+		}
+		ctx := c.UserContext()
+		record, err := st.Reserve(ctx, key, fingerprint(c))
+		if err != nil {
+			return err
+		}
+		if record != nil {
+			c.Set(HeaderReplayed, "true")
+			if record.ContentType != nil {
+				c.Set(fiber.HeaderContentType, *record.ContentType)
+			}
+			return c.Status(*record.StatusCode).Send(record.Response)
+		}
+		if err := c.Next(); err != nil {
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+		ctx = context.WithoutCancel(ctx)
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			st.Release(ctx, key)
+		} else {
+			st.Save(ctx, key, status, string(c.Response().Header.ContentType()), c.Response().Body())
+		}
+		return nil
+	}
+}
+
+func (st *Store) Start(context.Context) error {
+	go st.run()
+	return nil
+}
+
+func (st *Store) Stop(ctx context.Context) error {
+	close(st.stop)
+	select {
+	case <-st.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (st *Store) run() {
+	defer close(st.done)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-st.stop
+		cancel()
+	}()
+	ticker := time.NewTicker(st.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-st.stop:
+			return
+		case <-ticker.C:
+			if _, err := st.s.DeleteExpiredIdempotencyKeys(ctx, time.Now().UTC()); err != nil {
+				slog.ErrorContext(ctx, "can't delete expired idempotency keys", "component", "idempotency", "error", err)
+			}
+		}
+	}
+}
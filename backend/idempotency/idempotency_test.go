@@ -0,0 +1,177 @@
+package idempotency
+
+import (
+	"backend/entities"
+	"backend/service"
+	"context"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeKeyStore struct {
+	mu   sync.Mutex
+	keys map[string]entities.IdempotencyKey
+	// pending keys never get their response saved, as if the first request
+	// were still running.
+	pending map[string]bool
+}
+
+func (f *fakeKeyStore) ReserveIdempotencyKey(
+	_ context.Context,
+	key string,
+	fingerprint string,
+	_ time.Duration,
+	_ time.Duration,
+) (entities.IdempotencyKey, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if existing, ok := f.keys[key]; ok {
+		return existing, false, nil
+	}
+	record := entities.IdempotencyKey{Key: key, Fingerprint: fingerprint}
+	f.keys[key] = record
+	return record, true, nil
+}
+
+func (f *fakeKeyStore) SaveIdempotentResponse(_ context.Context, key string, statusCode int, contentType string, response []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.pending[key] {
+		return nil
+	}
+	record := f.keys[key]
+	record.StatusCode = &statusCode
+	record.ContentType = &contentType
+	record.Response = append([]byte(nil), response...)
+	f.keys[key] = record
+	return nil
+}
+
+func (f *fakeKeyStore) DeleteIdempotencyKey(_ context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.keys, key)
+	return nil
+}
+
+func (f *fakeKeyStore) DeleteExpiredIdempotencyKeys(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func errorHandler(c *fiber.Ctx, err error) error {
+	if serviceErr, ok := service.AsError(err); ok {
+		return c.Status(serviceErr.Code.Status).SendString(serviceErr.Code.Name)
+	}
+	return c.Status(fiber.StatusInternalServerError).SendString(err.Error())
+}
+
+type request struct {
+	key  string
+	body string
+}
+
+type response struct {
+	status   int
+	body     string
+	replayed bool
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		pending  []string
+		requests []request
+		want     []response
+		calls    int
+	}{
+		{
+			name:     "no key",
+			requests: []request{{"", "a"}, {"", "a"}},
+			want:     []response{{201, "created 1", false}, {201, "created 2", false}},
+			calls:    2,
+		},
+		{
+			name:     "repeat is replayed",
+			requests: []request{{"k", "a"}, {"k", "a"}},
+			want:     []response{{201, "created 1", false}, {201, "created 1", true}},
+			calls:    1,
+		},
+		{
+			name:     "different request with the same key",
+			requests: []request{{"k", "a"}, {"k", "b"}},
+			want:     []response{{201, "created 1", false}, {422, "IDEMPOTENCY_KEY_MISMATCH", false}},
+			calls:    1,
+		},
+		{
+			name:     "first request still running",
+			pending:  []string{"k"},
+			requests: []request{{"k", "a"}, {"k", "a"}},
+			want:     []response{{201, "created 1", false}, {409, "IDEMPOTENCY_KEY_IN_USE", false}},
+			calls:    1,
+		},
+		{
+			name:     "client error is replayed",
+			requests: []request{{"k", "invalid"}, {"k", "invalid"}},
+			want:     []response{{400, "INVALID_PARAMS", false}, {400, "INVALID_PARAMS", true}},
+			calls:    1,
+		},
+		{
+			name:     "server error releases the key",
+			requests: []request{{"k", "fail"}, {"k", "fail"}},
+			want:     []response{{500, "boom", false}, {500, "boom", false}},
+			calls:    2,
+		},
+		{
+			name:     "key too long",
+			requests: []request{{strings.Repeat("k", maxKeyLength+1), "a"}},
+			want:     []response{{400, "INVALID_PARAMS", false}},
+			calls:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := &fakeKeyStore{keys: make(map[string]entities.IdempotencyKey), pending: make(map[string]bool)}
+			for _, key := range tt.pending {
+				keys.pending[key] = true
+			}
+			st := &Store{s: keys, ttl: time.Hour}
+			calls := 0
+			app := fiber.New(fiber.Config{ErrorHandler: errorHandler})
+			app.Post("/", st.Middleware(), func(c *fiber.Ctx) error {
+				calls++
+				switch string(c.Body()) {
+				case "invalid":
+					return service.NewError(service.CodeInvalidParams, "")
+				case "fail":
+					return errors.New("boom")
+				}
+				return c.Status(fiber.StatusCreated).SendString("created " + strconv.Itoa(calls))
+			})
+			for i, r := range tt.requests {
+				req := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(r.body))
+				if len(r.key) > 0 {
+					req.Header.Set(HeaderKey, r.key)
+				}
+				resp, err := app.Test(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body, _ := io.ReadAll(resp.Body)
+				got := response{resp.StatusCode, string(body), resp.Header.Get(HeaderReplayed) == "true"}
+				if got != tt.want[i] {
+					t.Errorf("request %d = %+v, want %+v", i, got, tt.want[i])
+				}
+			}
+			if calls != tt.calls {
+				t.Errorf("handler called %d times, want %d", calls, tt.calls)
+			}
+		})
+	}
+}
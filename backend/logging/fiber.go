@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"log/slog"
+	"time"
+)
+
+// RequestId returns the id sent by the caller if it looks sane, otherwise a
+// new one.
+func RequestId(id string) string {
+	if len(id) == 0 || len(id) > 128 {
+		return uuid.NewString()
+	}
+	for _, r := range id {
+		if r < '!' || r > '~' {
+			return uuid.NewString()
+		}
+	}
+	return id
+}
+
+// Middleware accepts the caller's X-Request-ID or generates one, echoes it back
+// and writes one access log line per request.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		requestId := RequestId(c.Get(fiber.HeaderXRequestID))
+		c.Set(fiber.HeaderXRequestID, requestId)
+		ctx := WithFields(c.UserContext())
+		Set(ctx, "request_id", requestId)
+		c.SetUserContext(ctx)
+		if err := c.Next(); err != nil {
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+		status := c.Response().StatusCode()
+		level := slog.LevelInfo
+		if status >= fiber.StatusInternalServerError {
+			level = slog.LevelError
+		} else if status >= fiber.StatusBadRequest {
+			level = slog.LevelWarn
+		}
+		slog.Log(c.UserContext(), level, "request",
+			"method", c.Method(),
+			"route", c.Route().Path,
+			"path", c.Path(),
+			"status", status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"ip", c.IP(),
+		)
+		return nil
+	}
+}
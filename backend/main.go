@@ -1,7 +1,54 @@
 package main
 
-import "backend/server"
+import (
+	"backend/config"
+	"backend/server"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+const usage = `Usage:
+  backend [-config path]               run the server
+  backend config print [-config path]  print the effective configuration
+`
 
 func main() {
-	server.BuildServerAndEnv().Run()
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	printConfig := len(args) >= 2 && args[0] == "config" && args[1] == "print"
+	if printConfig {
+		args = args[2:]
+	}
+	flags := flag.NewFlagSet("backend", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	path := flags.String("config", config.DefaultPath, "path to the YAML config file")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() > 0 {
+		flags.Usage()
+		return fmt.Errorf("unknown command %q", flags.Arg(0))
+	}
+	cfg, err := config.Load(*path)
+	if err != nil {
+		return err
+	}
+	if printConfig {
+		return cfg.Print(os.Stdout)
+	}
+	server.BuildServerAndEnv(cfg).Run()
+	return nil
 }
@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"database/sql"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"strconv"
+	"time"
+)
+
+type Metrics struct {
+	registry         *prometheus.Registry
+	requests         *prometheus.CounterVec
+	requestDuration  *prometheus.HistogramVec
+	storageDuration  *prometheus.HistogramVec
+	tendersCreated   prometheus.Counter
+	tendersPublished prometheus.Counter
+	bidsSubmitted    prometheus.Counter
+	decisions        *prometheus.CounterVec
+}
+
+func NewMetrics(db *sql.DB) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests by route, method and status.",
+		}, []string{"route", "method", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request duration by route and method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		storageDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "storage_duration_seconds",
+			Help:    "Storage call duration by method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method"}),
+		tendersCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tenders_created_total",
+			Help: "Tenders created.",
+		}),
+		tendersPublished: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tenders_published_total",
+			Help: "Tenders moved to the Published status.",
+		}),
+		bidsSubmitted: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "bids_submitted_total",
+			Help: "Bids submitted.",
+		}),
+		decisions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "decisions_total",
+			Help: "Decisions on bids by outcome.",
+		}, []string{"outcome"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(db, "postgres"),
+		m.requests,
+		m.requestDuration,
+		m.storageDuration,
+		m.tendersCreated,
+		m.tendersPublished,
+		m.bidsSubmitted,
+		m.decisions,
+	)
+	return m
+}
+
+func (m *Metrics) Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+}
+
+// Middleware records every request under its route pattern rather than the
+// raw path so that ids don't blow up the label cardinality. Requests that only
+// went through middlewares are labeled "unmatched". Errors are passed to the
+// error handler right away to know the final status.
+func (m *Metrics) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		if err := c.Next(); err != nil {
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+		route := c.Route().Path
+		if route == "/" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Response().StatusCode())
+		m.requests.WithLabelValues(route, c.Method(), status).Inc()
+		m.requestDuration.WithLabelValues(route, c.Method()).Observe(time.Since(start).Seconds())
+		return nil
+	}
+}
+
+func (m *Metrics) ObserveStorage(method string, duration time.Duration) {
+	m.storageDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+func (m *Metrics) TenderCreated() {
+	m.tendersCreated.Inc()
+}
+
+func (m *Metrics) TenderPublished() {
+	m.tendersPublished.Inc()
+}
+
+func (m *Metrics) BidSubmitted() {
+	m.bidsSubmitted.Inc()
+}
+
+func (m *Metrics) DecisionMade(outcome string) {
+	m.decisions.WithLabelValues(outcome).Inc()
+}
@@ -0,0 +1,33 @@
+-- +goose Up
+
+-- +goose StatementBegin
+ALTER TYPE bid_status ADD VALUE 'Withdrawn';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE tender ADD COLUMN deadline TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE bid
+    ADD COLUMN withdrawal_reason TEXT,
+    ADD COLUMN withdrawn_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+    ADD COLUMN withdrawn_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+
+-- +goose StatementBegin
+UPDATE bid SET status='Cancelled' WHERE status='Withdrawn';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE bid
+    DROP COLUMN withdrawn_at,
+    DROP COLUMN withdrawn_by,
+    DROP COLUMN withdrawal_reason;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE tender DROP COLUMN deadline;
+-- +goose StatementEnd
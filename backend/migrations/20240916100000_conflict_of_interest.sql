@@ -0,0 +1,38 @@
+-- +goose Up
+
+-- +goose StatementBegin
+CREATE TYPE conflict_policy AS ENUM (
+    'Block',
+    'Flag'
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE organization ADD COLUMN conflict_policy conflict_policy NOT NULL DEFAULT 'Block';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE bid ADD COLUMN conflict_of_interest BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE bid_decision ADD COLUMN conflict_of_interest BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+
+-- +goose StatementBegin
+ALTER TABLE bid_decision DROP COLUMN conflict_of_interest;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE bid DROP COLUMN conflict_of_interest;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE organization DROP COLUMN conflict_policy;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TYPE conflict_policy;
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+
+-- +goose StatementBegin
+CREATE TYPE qualification_status AS ENUM (
+    'Pending',
+    'Approved',
+    'Rejected'
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE qualification (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    supplier_type author_type NOT NULL,
+    supplier_id UUID NOT NULL,
+    organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    service_type service_type NOT NULL,
+    description TEXT NOT NULL,
+    status qualification_status NOT NULL,
+    expires_at TIMESTAMP,
+    reviewed_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX qualification_supplier_idx ON qualification (supplier_type, supplier_id, organization_id, service_type);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE tender ADD COLUMN require_qualification BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+
+-- +goose StatementBegin
+ALTER TABLE tender DROP COLUMN require_qualification;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE qualification;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TYPE qualification_status;
+-- +goose StatementEnd
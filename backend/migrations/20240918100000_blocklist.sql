@@ -0,0 +1,24 @@
+-- +goose Up
+
+-- +goose StatementBegin
+CREATE TABLE blocklist_entry (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    subject_type author_type NOT NULL,
+    subject_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    expires_at TIMESTAMP,
+    created_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX blocklist_entry_subject_idx ON blocklist_entry (organization_id, subject_type, subject_id);
+-- +goose StatementEnd
+
+-- +goose Down
+
+-- +goose StatementBegin
+DROP TABLE blocklist_entry;
+-- +goose StatementEnd
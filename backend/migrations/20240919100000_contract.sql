@@ -0,0 +1,46 @@
+-- +goose Up
+
+-- +goose StatementBegin
+ALTER TABLE bid ADD COLUMN amount NUMERIC(18, 2);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TYPE contract_status AS ENUM (
+    'Draft',
+    'Signed',
+    'Completed',
+    'Terminated'
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE contract (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tender_id UUID UNIQUE NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    tender_version INTEGER NOT NULL,
+    bid_id UUID UNIQUE NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    bid_version INTEGER NOT NULL,
+    buyer_organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    supplier_type author_type NOT NULL,
+    supplier_id UUID NOT NULL,
+    amount NUMERIC(18, 2),
+    status contract_status NOT NULL,
+    version INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+
+-- +goose StatementBegin
+DROP TABLE contract;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TYPE contract_status;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE bid DROP COLUMN amount;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- +goose StatementBegin
+ALTER TABLE tender
+    ADD COLUMN budget NUMERIC(18, 2),
+    ADD COLUMN currency VARCHAR(3),
+    ADD COLUMN budget_public BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN reject_over_budget BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+
+-- +goose StatementBegin
+ALTER TABLE tender
+    DROP COLUMN reject_over_budget,
+    DROP COLUMN budget_public,
+    DROP COLUMN currency,
+    DROP COLUMN budget;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+
+-- +goose StatementBegin
+ALTER TABLE tender
+    ADD COLUMN auction_starts_at TIMESTAMP,
+    ADD COLUMN auction_ends_at TIMESTAMP,
+    ADD COLUMN auction_extension_seconds INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE bid ADD COLUMN auction_rank INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+
+-- +goose StatementBegin
+ALTER TABLE bid DROP COLUMN auction_rank;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE tender
+    DROP COLUMN auction_extension_seconds,
+    DROP COLUMN auction_ends_at,
+    DROP COLUMN auction_starts_at;
+-- +goose StatementEnd
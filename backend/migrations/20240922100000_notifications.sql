@@ -0,0 +1,55 @@
+-- +goose Up
+
+-- +goose StatementBegin
+ALTER TABLE employee ADD COLUMN email VARCHAR(255);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE tender ADD COLUMN deadline_notified BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE notification (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+    tender_id UUID REFERENCES tender(id) ON DELETE CASCADE,
+    bid_id UUID REFERENCES bid(id) ON DELETE CASCADE,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX notification_user_idx ON notification (user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE notification_preference (
+    user_id UUID PRIMARY KEY REFERENCES employee(id) ON DELETE CASCADE,
+    language VARCHAR(2) NOT NULL,
+    email_enabled BOOLEAN NOT NULL,
+    in_app_enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+
+-- +goose StatementBegin
+DROP TABLE notification_preference;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE notification;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE tender DROP COLUMN deadline_notified;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE employee DROP COLUMN email;
+-- +goose StatementEnd
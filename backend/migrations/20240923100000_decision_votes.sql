@@ -0,0 +1,27 @@
+-- +goose Up
+
+-- +goose StatementBegin
+ALTER TABLE bid_decision ADD COLUMN user_id UUID REFERENCES employee(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE bid_decision ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX bid_decision_bid_idx ON bid_decision (bid_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+
+-- +goose StatementBegin
+DROP INDEX bid_decision_bid_idx;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE bid_decision DROP COLUMN created_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE bid_decision DROP COLUMN user_id;
+-- +goose StatementEnd
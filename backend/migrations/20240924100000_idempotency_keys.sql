@@ -0,0 +1,23 @@
+-- +goose Up
+
+-- +goose StatementBegin
+CREATE TABLE idempotency_key (
+    key VARCHAR(255) PRIMARY KEY,
+    fingerprint CHAR(64) NOT NULL,
+    status_code INT,
+    content_type VARCHAR(255),
+    response BYTEA,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX idempotency_key_expires_idx ON idempotency_key (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+
+-- +goose StatementBegin
+DROP TABLE idempotency_key;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- +goose StatementBegin
+ALTER TYPE bid_status ADD VALUE 'Closed';
+-- +goose StatementEnd
+
+-- +goose Down
+
+-- +goose StatementBegin
+UPDATE bid SET status='Cancelled' WHERE status='Closed';
+-- +goose StatementEnd
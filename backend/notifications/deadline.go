@@ -0,0 +1,104 @@
+package notifications
+
+import (
+	"backend/config"
+	"backend/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+type DeadlineWorker struct {
+	s        *storage.Storage
+	n        *Service
+	interval time.Duration
+	warning  time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+	running  atomic.Bool
+	lastTick atomic.Int64
+}
+
+func NewDeadlineWorker(s *storage.Storage, n *Service, cfg *config.Config) *DeadlineWorker {
+	notificationsConfig := cfg.GetNotificationsConfig()
+	return &DeadlineWorker{
+		s:        s,
+		n:        n,
+		interval: notificationsConfig.GetPollInterval(),
+		warning:  notificationsConfig.GetDeadlineWarning(),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (w *DeadlineWorker) Start(context.Context) error {
+	go w.run()
+	return nil
+}
+
+func (w *DeadlineWorker) Stop(ctx context.Context) error {
+	close(w.stop)
+	select {
+	case <-w.done:
+		return w.n.Wait(ctx)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Check reports whether the worker loop is running and keeps ticking.
+func (w *DeadlineWorker) Check(context.Context) error {
+	if !w.running.Load() {
+		return errors.New("not running")
+	}
+	if lastTick := time.Unix(0, w.lastTick.Load()); time.Since(lastTick) > 3*w.interval {
+		return fmt.Errorf("stalled, last tick at %s", lastTick.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (w *DeadlineWorker) run() {
+	defer close(w.done)
+	w.running.Store(true)
+	defer w.running.Store(false)
+	w.lastTick.Store(time.Now().UnixNano())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-w.stop
+		cancel()
+	}()
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.notifyDeadlines(ctx)
+			w.lastTick.Store(time.Now().UnixNano())
+		}
+	}
+}
+
+func (w *DeadlineWorker) notifyDeadlines(ctx context.Context) {
+	tenders, err := w.s.GetTendersWithApproachingDeadline(ctx, time.Now().UTC().Add(w.warning))
+	if err != nil {
+		slog.ErrorContext(ctx, "can't get tenders with approaching deadline", "component", "notifications", "error", err)
+		return
+	}
+	for _, tender := range tenders {
+		if err := w.n.DeadlineApproaching(ctx, tender); err != nil {
+			slog.ErrorContext(ctx, "can't notify about deadline", "component", "notifications", "tender_id", tender.Id,
+				"error", err)
+			continue
+		}
+		if err := w.s.MarkDeadlineNotified(ctx, tender.Id); err != nil {
+			slog.ErrorContext(ctx, "can't mark deadline as notified", "component", "notifications", "tender_id", tender.Id,
+				"error", err)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package notifications
+
+import (
+	"backend/config"
+	"backend/entities"
+	"backend/entities/author_type"
+	"backend/entities/notification_kind"
+	"backend/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"sync"
+)
+
+type Service struct {
+	s               *storage.Storage
+	sender          Sender
+	defaultLanguage string
+	wg              sync.WaitGroup
+}
+
+func NewService(s *storage.Storage, sender Sender, cfg *config.Config) *Service {
+	return &Service{
+		s:               s,
+		sender:          sender,
+		defaultLanguage: cfg.GetNotificationsConfig().GetDefaultLanguage(),
+	}
+}
+
+type templateData struct {
+	Tender   entities.Tender
+	Bid      entities.Bid
+	Decision string
+}
+
+func (n *Service) BidCreated(ctx context.Context, tender entities.Tender, bid entities.Bid) {
+	n.dispatch(ctx, func(ctx context.Context) error {
+		userIds, err := n.s.GetOrganizationResponsibleIds(ctx, tender.OrganizationId)
+		if err != nil {
+			return err
+		}
+		data := templateData{Tender: tender, Bid: bid}
+		return n.notify(ctx, userIds, notification_kind.BID_CREATED, data, &tender.Id, &bid.Id)
+	})
+}
+
+func (n *Service) DecisionMade(ctx context.Context, tender entities.Tender, bid entities.Bid, decision string) {
+	n.dispatch(ctx, func(ctx context.Context) error {
+		userIds := []string{bid.AuthorId}
+		if bid.AuthorType == author_type.ORGANIZATION {
+			var err error
+			userIds, err = n.s.GetOrganizationResponsibleIds(ctx, bid.AuthorId)
+			if err != nil {
+				return err
+			}
+		}
+		data := templateData{Tender: tender, Bid: bid, Decision: decision}
+		return n.notify(ctx, userIds, notification_kind.DECISION_MADE, data, &tender.Id, &bid.Id)
+	})
+}
+
+func (n *Service) DeadlineApproaching(ctx context.Context, tender entities.Tender) error {
+	userIds, err := n.s.GetOrganizationResponsibleIds(ctx, tender.OrganizationId)
+	if err != nil {
+		return err
+	}
+	return n.notify(ctx, userIds, notification_kind.DEADLINE_APPROACHING, templateData{Tender: tender}, &tender.Id, nil)
+}
+
+func (n *Service) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (n *Service) dispatch(ctx context.Context, send func(ctx context.Context) error) {
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		ctx := context.WithoutCancel(ctx)
+		if err := send(ctx); err != nil {
+			slog.ErrorContext(ctx, "can't send notification", "component", "notifications", "error", err)
+		}
+	}()
+}
+
+func (n *Service) preference(ctx context.Context, userId string) (entities.NotificationPreference, error) {
+	preference, err := n.s.GetNotificationPreference(ctx, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entities.NotificationPreference{
+			UserId:       userId,
+			Language:     n.defaultLanguage,
+			EmailEnabled: true,
+			InAppEnabled: true,
+		}, nil
+	}
+	return preference, err
+}
+
+func (n *Service) notify(ctx context.Context, userIds []string, kind string, data templateData, tenderId *string, bidId *string) error {
+	var errs []error
+	for _, userId := range userIds {
+		preference, err := n.preference(ctx, userId)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		msg, err := render(kind, preference.Language, data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if preference.InAppEnabled {
+			_, err := n.s.CreateNotification(ctx, entities.Notification{
+				UserId:   userId,
+				Kind:     kind,
+				Title:    msg.title,
+				Body:     msg.body,
+				TenderId: tenderId,
+				BidId:    bidId,
+			})
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if preference.EmailEnabled {
+			user, err := n.s.GetUser(ctx, userId)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if user.Email != nil {
+				if err := n.sender.Send(*user.Email, msg.title, msg.body); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,43 @@
+package notifications
+
+import (
+	"backend/config"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+type Sender interface {
+	Send(to string, subject string, body string) error
+}
+
+type SMTPSender struct {
+	cfg config.ConfigSMTP
+}
+
+func NewSMTPSender(cfg *config.Config) *SMTPSender {
+	return &SMTPSender{cfg: cfg.GetNotificationsConfig().SMTP}
+}
+
+func (s SMTPSender) Send(to string, subject string, body string) error {
+	if len(s.cfg.Host) == 0 {
+		return nil
+	}
+	var auth smtp.Auth = nil
+	if len(s.cfg.Username) > 0 {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+	headers := []string{
+		"From: " + s.cfg.From,
+		"To: " + to,
+		"Subject: " + mime.QEncoding.Encode("utf-8", subject),
+		"Date: " + time.Now().UTC().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding: 8bit",
+	}
+	msg := fmt.Sprintf("%s\r\n\r\n%s\r\n", strings.Join(headers, "\r\n"), body)
+	return smtp.SendMail(s.cfg.GetAddress(), auth, s.cfg.From, []string{to}, []byte(msg))
+}
@@ -0,0 +1,75 @@
+package notifications
+
+import (
+	"backend/entities/language"
+	"backend/entities/notification_kind"
+	"bytes"
+	"text/template"
+)
+
+type message struct {
+	title string
+	body  string
+}
+
+type messageTemplate struct {
+	title *template.Template
+	body  *template.Template
+}
+
+func newMessageTemplate(title string, body string) messageTemplate {
+	return messageTemplate{
+		title: template.Must(template.New("title").Parse(title)),
+		body:  template.Must(template.New("body").Parse(body)),
+	}
+}
+
+var templates = map[string]map[string]messageTemplate{
+	notification_kind.BID_CREATED: {
+		language.RU: newMessageTemplate(
+			"Новое предложение по тендеру «{{.Tender.Name}}»",
+			"По тендеру «{{.Tender.Name}}» поступило новое предложение «{{.Bid.Name}}».",
+		),
+		language.EN: newMessageTemplate(
+			"New bid on tender \"{{.Tender.Name}}\"",
+			"Tender \"{{.Tender.Name}}\" has received a new bid \"{{.Bid.Name}}\".",
+		),
+	},
+	notification_kind.DECISION_MADE: {
+		language.RU: newMessageTemplate(
+			"Решение по предложению «{{.Bid.Name}}»",
+			"По вашему предложению «{{.Bid.Name}}» к тендеру «{{.Tender.Name}}» принято решение: "+
+				"{{if eq .Decision \"Approved\"}}одобрено{{else}}отклонено{{end}}.",
+		),
+		language.EN: newMessageTemplate(
+			"Decision on bid \"{{.Bid.Name}}\"",
+			"Your bid \"{{.Bid.Name}}\" on tender \"{{.Tender.Name}}\" has been {{.Decision}}.",
+		),
+	},
+	notification_kind.DEADLINE_APPROACHING: {
+		language.RU: newMessageTemplate(
+			"Скоро срок тендера «{{.Tender.Name}}»",
+			"Приём предложений по тендеру «{{.Tender.Name}}» завершается {{.Tender.Deadline.Format \"02.01.2006 15:04\"}} UTC.",
+		),
+		language.EN: newMessageTemplate(
+			"Tender \"{{.Tender.Name}}\" deadline is approaching",
+			"Bids on tender \"{{.Tender.Name}}\" are accepted until {{.Tender.Deadline.Format \"2006-01-02 15:04\"}} UTC.",
+		),
+	},
+}
+
+func render(kind string, lang string, data any) (message, error) {
+	byLanguage := templates[kind]
+	tmpl, ok := byLanguage[lang]
+	if !ok {
+		tmpl = byLanguage[language.RU]
+	}
+	var title, body bytes.Buffer
+	if err := tmpl.title.Execute(&title, data); err != nil {
+		return message{}, err
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return message{}, err
+	}
+	return message{title: title.String(), body: body.String()}, nil
+}
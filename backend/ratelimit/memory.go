@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time
+}
+
+type memoryBackend struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	stopped chan struct{}
+}
+
+func newMemoryBackend() *memoryBackend {
+	return &memoryBackend{buckets: make(map[string]*bucket), stopped: make(chan struct{})}
+}
+
+func (m *memoryBackend) take(_ context.Context, key string, r rule, now time.Time) (float64, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(r.burst), updated: now}
+		m.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(float64(r.burst), b.tokens+elapsed.Seconds()*r.rate)
+		b.updated = now
+	}
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	b.full = now.Add(seconds((float64(r.burst) - b.tokens) / r.rate))
+	return b.tokens, allowed, nil
+}
+
+// sweep forgets buckets that have refilled, they are the same as new ones.
+func (m *memoryBackend) sweep(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, b := range m.buckets {
+		if !now.Before(b.full) {
+			delete(m.buckets, key)
+		}
+	}
+}
+
+func (m *memoryBackend) start(context.Context) error {
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.stopped:
+				return
+			case now := <-ticker.C:
+				m.sweep(now)
+			}
+		}
+	}()
+	return nil
+}
+
+func (m *memoryBackend) stop(context.Context) error {
+	close(m.stopped)
+	return nil
+}
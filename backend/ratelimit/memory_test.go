@@ -0,0 +1,121 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryTake(t *testing.T) {
+	start := time.Date(2024, 9, 25, 12, 0, 0, 0, time.UTC)
+	r := rule{rate: 1, burst: 2}
+	type take struct {
+		key     string
+		after   time.Duration
+		tokens  float64
+		allowed bool
+	}
+	tests := []struct {
+		name  string
+		takes []take
+	}{
+		{
+			name: "burst then empty",
+			takes: []take{
+				{"a", 0, 1, true},
+				{"a", 0, 0, true},
+				{"a", 0, 0, false},
+			},
+		},
+		{
+			name: "refills with time",
+			takes: []take{
+				{"a", 0, 1, true},
+				{"a", 0, 0, true},
+				{"a", 500 * time.Millisecond, 0.5, false},
+				{"a", time.Second, 0, true},
+			},
+		},
+		{
+			name: "refill is capped by burst",
+			takes: []take{
+				{"a", 0, 1, true},
+				{"a", time.Hour, 1, true},
+			},
+		},
+		{
+			name: "keys are independent",
+			takes: []take{
+				{"a", 0, 1, true},
+				{"a", 0, 0, true},
+				{"b", 0, 1, true},
+				{"a", 0, 0, false},
+			},
+		},
+		{
+			name: "clock going back doesn't refill",
+			takes: []take{
+				{"a", time.Second, 1, true},
+				{"a", 0, 0, true},
+				{"a", 0, 0, false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMemoryBackend()
+			for i, step := range tt.takes {
+				tokens, allowed, err := m.take(context.Background(), step.key, r, start.Add(step.after))
+				if err != nil {
+					t.Fatalf("take %d: error = %v", i, err)
+				}
+				if tokens != step.tokens || allowed != step.allowed {
+					t.Errorf("take %d = %v, %v, want %v, %v", i, tokens, allowed, step.tokens, step.allowed)
+				}
+			}
+		})
+	}
+}
+
+func TestMemorySweep(t *testing.T) {
+	start := time.Date(2024, 9, 25, 12, 0, 0, 0, time.UTC)
+	r := rule{rate: 1, burst: 2}
+	m := newMemoryBackend()
+	for _, key := range []string{"a", "a", "b"} {
+		if _, _, err := m.take(context.Background(), key, r, start); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m.sweep(start.Add(time.Second))
+	if _, ok := m.buckets["b"]; ok {
+		t.Error("refilled bucket b was kept")
+	}
+	if _, ok := m.buckets["a"]; !ok {
+		t.Error("bucket a was dropped before it refilled")
+	}
+	m.sweep(start.Add(2 * time.Second))
+	if len(m.buckets) != 0 {
+		t.Errorf("%d buckets left after all refilled", len(m.buckets))
+	}
+}
+
+func TestNewResult(t *testing.T) {
+	r := rule{rate: 2, burst: 10}
+	tests := []struct {
+		name    string
+		tokens  float64
+		allowed bool
+		want    Result
+	}{
+		{"full", 10, true, Result{Allowed: true, Limit: 10, Remaining: 10}},
+		{"partial", 4.5, true, Result{Allowed: true, Limit: 10, Remaining: 4, Reset: 2750 * time.Millisecond}},
+		{"empty", 0.5, false, Result{Limit: 10, Reset: 4750 * time.Millisecond, RetryAfter: 250 * time.Millisecond}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newResult(r, tt.tokens, tt.allowed); got != tt.want {
+				t.Errorf("newResult() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package ratelimit
+
+import (
+	"backend/config"
+	"context"
+	"errors"
+	"go.uber.org/fx"
+	"log/slog"
+	"math"
+	"time"
+)
+
+type rule struct {
+	rate  float64
+	burst int
+}
+
+func newRule(cfg config.ConfigRate) rule {
+	return rule{rate: cfg.GetRate(), burst: cfg.GetBurst()}
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+func newResult(r rule, tokens float64, allowed bool) Result {
+	result := Result{
+		Allowed:   allowed,
+		Limit:     r.burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(r.burst) - tokens) / r.rate),
+	}
+	if !allowed {
+		result.RetryAfter = seconds((1 - tokens) / r.rate)
+	}
+	return result
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Max(s, 0) * float64(time.Second))
+}
+
+// backend takes one token from the bucket under key and reports how many are
+// left.
+type backend interface {
+	take(ctx context.Context, key string, r rule, now time.Time) (tokens float64, allowed bool, err error)
+}
+
+type budget struct {
+	ip   rule
+	user rule
+}
+
+type Limiter struct {
+	enabled bool
+	backend backend
+	read    budget
+	write   budget
+}
+
+func NewLimiter(lc fx.Lifecycle, cfg *config.Config) (*Limiter, error) {
+	limitConfig := cfg.GetRateLimitConfig()
+	l := &Limiter{
+		enabled: limitConfig.IsEnabled(),
+		read:    budget{ip: newRule(limitConfig.GetRead().IP), user: newRule(limitConfig.GetRead().User)},
+		write:   budget{ip: newRule(limitConfig.GetWrite().IP), user: newRule(limitConfig.GetWrite().User)},
+	}
+	if !l.enabled {
+		return l, nil
+	}
+	switch limitConfig.GetBackend() {
+	case "redis":
+		redisConfig := limitConfig.GetRedis()
+		if len(redisConfig.GetAddress()) == 0 {
+			return nil, errors.New("rate_limit.redis.address is required for the redis backend")
+		}
+		b := newRedisBackend(redisConfig)
+		lc.Append(fx.Hook{OnStop: func(context.Context) error { return b.client.Close() }})
+		l.backend = b
+	default:
+		b := newMemoryBackend()
+		lc.Append(fx.Hook{OnStart: b.start, OnStop: b.stop})
+		l.backend = b
+	}
+	return l, nil
+}
+
+func (l *Limiter) IsEnabled() bool {
+	return l.enabled
+}
+
+// Allow spends a token from the IP bucket and, if the caller named a user,
+// from the user bucket. Write requests use their own, usually smaller,
+// budget. The returned result is the most restrictive of the two. When the
+// backend fails the request is let through.
+func (l *Limiter) Allow(ctx context.Context, write bool, ip string, user string) Result {
+	b, class := l.read, "read"
+	if write {
+		b, class = l.write, "write"
+	}
+	var result *Result
+	check := func(r rule, kind string, id string) bool {
+		if r.rate <= 0 || len(id) == 0 {
+			return true
+		}
+		tokens, allowed, err := l.backend.take(ctx, class+":"+kind+":"+id, r, time.Now())
+		if err != nil {
+			slog.WarnContext(ctx, "rate limit backend failed", "component", "ratelimit", "error", err)
+			return true
+		}
+		current := newResult(r, tokens, allowed)
+		if result == nil || !allowed || current.Remaining < result.Remaining {
+			result = &current
+		}
+		return allowed
+	}
+	if check(b.ip, "ip", ip) {
+		check(b.user, "user", user)
+	}
+	if result == nil {
+		return Result{Allowed: true}
+	}
+	return *result
+}
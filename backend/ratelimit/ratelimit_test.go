@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAllow(t *testing.T) {
+	type call struct {
+		write   bool
+		ip      string
+		user    string
+		allowed bool
+	}
+	tests := []struct {
+		name  string
+		calls []call
+	}{
+		{
+			name: "user budget is shared between addresses",
+			calls: []call{
+				{false, "10.0.0.1", "alice", true},
+				{false, "10.0.0.2", "alice", true},
+				{false, "10.0.0.3", "alice", false},
+				{false, "10.0.0.3", "bob", true},
+			},
+		},
+		{
+			name: "address budget is shared between users",
+			calls: []call{
+				{false, "10.0.0.1", "alice", true},
+				{false, "10.0.0.1", "bob", true},
+				{false, "10.0.0.1", "carol", true},
+				{false, "10.0.0.1", "dave", false},
+			},
+		},
+		{
+			name: "anonymous requests only spend the address budget",
+			calls: []call{
+				{false, "10.0.0.1", "", true},
+				{false, "10.0.0.1", "", true},
+				{false, "10.0.0.1", "", true},
+				{false, "10.0.0.1", "", false},
+			},
+		},
+		{
+			name: "reads and writes have separate budgets",
+			calls: []call{
+				{true, "10.0.0.1", "alice", true},
+				{true, "10.0.0.1", "alice", false},
+				{false, "10.0.0.1", "alice", true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &Limiter{
+				enabled: true,
+				backend: newMemoryBackend(),
+				read:    budget{ip: rule{rate: 0.001, burst: 3}, user: rule{rate: 0.001, burst: 2}},
+				write:   budget{ip: rule{rate: 0.001, burst: 3}, user: rule{rate: 0.001, burst: 1}},
+			}
+			for i, c := range tt.calls {
+				if got := l.Allow(context.Background(), c.write, c.ip, c.user); got.Allowed != c.allowed {
+					t.Errorf("call %d: allowed = %v, want %v", i, got.Allowed, c.allowed)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"backend/config"
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+const keyPrefix = "ratelimit:"
+
+// takeScript refills and spends the bucket atomically. The caller's clock is
+// used, so replicas need roughly synchronized time.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(state[1]) or burst
+local updated = tonumber(state[2]) or now
+if now > updated then
+	tokens = math.min(burst, tokens + (now - updated) / 1000 * rate)
+	updated = now
+end
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", updated)
+redis.call("PEXPIRE", KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+type redisBackend struct {
+	client *redis.Client
+}
+
+func newRedisBackend(cfg config.ConfigRedis) *redisBackend {
+	return &redisBackend{client: redis.NewClient(&redis.Options{
+		Addr:     cfg.GetAddress(),
+		Password: cfg.GetPassword(),
+		DB:       cfg.GetDB(),
+	})}
+}
+
+func (r *redisBackend) take(ctx context.Context, key string, rl rule, now time.Time) (float64, bool, error) {
+	args := []any{rl.rate, rl.burst, now.UnixMilli()}
+	reply, err := takeScript.Run(ctx, r.client, []string{keyPrefix + key}, args...).Slice()
+	if err != nil {
+		return 0, false, err
+	}
+	allowed, _ := reply[0].(int64)
+	tokensReply, _ := reply[1].(string)
+	tokens, err := strconv.ParseFloat(tokensReply, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("unexpected reply %v: %w", reply, err)
+	}
+	return tokens, allowed == 1, nil
+}
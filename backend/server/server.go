@@ -1,62 +1,273 @@
 package server
 
 import (
+	"backend/auction"
 	"backend/config"
+	"backend/conflict"
+	"backend/db"
+	"backend/events"
+	"backend/grpcapi"
 	"backend/handlers"
+	"backend/health"
+	"backend/idempotency"
+	"backend/logging"
+	"backend/metrics"
+	"backend/notifications"
+	"backend/ratelimit"
+	"backend/service"
 	"backend/storage"
+	"backend/tracing"
 	"context"
+	"database/sql"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+	"google.golang.org/grpc"
+	"log/slog"
+	"net"
+	"time"
 )
 
-func buildFiberServer(lc fx.Lifecycle, h *handlers.Handlers, c *config.Config) *fiber.App {
-	app := fiber.New()
+func buildFiberServer(
+	lc fx.Lifecycle,
+	h *handlers.Handlers,
+	m *metrics.Metrics,
+	t *tracing.Tracing,
+	l *ratelimit.Limiter,
+	idem *idempotency.Store,
+	c *config.Config,
+) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: handlers.ErrorHandler})
 	app.Use(cors.New())
-	app.Use(logger.New())
+	app.Get("/healthz", h.Healthz)
+	app.Get("/readyz", h.Readyz)
+	app.Use(t.Middleware())
+	app.Use(logging.Middleware())
+	if metricsConfig := c.GetMetricsConfig(); metricsConfig.IsEnabled() {
+		app.Use(m.Middleware())
+		app.Get(metricsConfig.GetPath(), m.Handler())
+	}
+	if l.IsEnabled() {
+		app.Use(handlers.RateLimit(l))
+	}
+	app.Use(handlers.Timeout(c.GetHTTPConfig()))
 
 	api := app.Group("/api")
 	api.Get("/ping", h.Ping)
+	api.Get("/events", h.SubscribeEvents)
 	tenders := api.Group("/tenders")
-	tenders.Post("/new", h.CreateTender)
+	tenders.Post("/new", idem.Middleware(), h.CreateTender)
+	tenders.Post("/import", h.ImportTenders)
 	tenders.Get("/", h.FilterTenders)
 	tenders.Get("/my", h.FilterMyTenders)
 	tendersCRUD := tenders.Group("/:tenderId")
 	tendersCRUD.Get("/status", h.GetTenderStatus)
 	tendersCRUD.Put("/status", h.UpdateTenderStatus)
 	tendersCRUD.Patch("/edit", h.EditTender)
+	tendersCRUD.Get("/auction", h.GetAuction)
 	bids := api.Group("/bids")
-	bids.Post("/new", h.CreateBid)
+	bids.Post("/new", idem.Middleware(), h.CreateBid)
 	bids.Get("/my", h.GetMyBids)
 	tendersCRUD.Get("/list", h.GetTenderBids)
 	bidsCRUD := bids.Group("/:bidId")
 	bidsCRUD.Get("/status", h.GetBidStatus)
 	bidsCRUD.Put("/status", h.ChangeBidStatus)
 	bidsCRUD.Patch("/edit", h.EditBid)
-	bidsCRUD.Put("/submit_decision", h.SetDecision)
+	bidsCRUD.Put("/withdraw", h.WithdrawBid)
+	bidsCRUD.Put("/submit_decision", idem.Middleware(), h.SetDecision)
 	bidsCRUD.Get("/get_decision", h.GetDecision)
+	qualifications := api.Group("/qualifications")
+	qualifications.Post("/new", h.CreateQualification)
+	qualifications.Get("/my", h.GetMyQualifications)
+	qualifications.Get("/review", h.GetQualificationsToReview)
+	qualifications.Put("/:qualificationId/review", h.ReviewQualification)
+	contracts := api.Group("/contracts")
+	contracts.Get("/my", h.GetMyContracts)
+	contracts.Get("/:contractId", h.GetContract)
+	contracts.Put("/:contractId/status", h.ChangeContractStatus)
+	notificationsGroup := api.Group("/notifications")
+	notificationsGroup.Get("/", h.GetNotifications)
+	notificationsGroup.Get("/preferences", h.GetNotificationPreference)
+	notificationsGroup.Put("/preferences", h.SetNotificationPreference)
+	notificationsGroup.Put("/:notificationId/read", h.MarkNotificationRead)
+	organizationsCRUD := api.Group("/organizations/:organizationId")
+	organizationsCRUD.Put("/conflict_policy", h.SetConflictPolicy)
+	organizationsCRUD.Post("/blocklist", h.CreateBlocklistEntry)
+	organizationsCRUD.Get("/blocklist", h.GetBlocklist)
+	organizationsCRUD.Delete("/blocklist/:entryId", h.DeleteBlocklistEntry)
 
 	lc.Append(fx.Hook{
 		OnStart: func(context.Context) error {
-			go app.Listen(c.GetServerAddress())
+			listener, err := net.Listen("tcp", c.GetServerAddress())
+			if err != nil {
+				return err
+			}
+			go func() {
+				if err := app.Listener(listener); err != nil {
+					slog.Error("http server stopped", "error", err)
+				}
+			}()
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
-			return app.Shutdown()
+			ctx, cancel := context.WithTimeout(ctx, c.GetHTTPConfig().GetShutdownTimeout())
+			defer cancel()
+			return app.ShutdownWithContext(ctx)
 		},
 	})
 
 	return app
 }
 
-func BuildServerAndEnv() *fx.App {
+func startGRPCServer(lc fx.Lifecycle, server *grpc.Server, c *config.Config) {
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			listener, err := net.Listen("tcp", c.GetGRPCConfig().GetAddress())
+			if err != nil {
+				return err
+			}
+			go func() {
+				if err := server.Serve(listener); err != nil {
+					slog.Error("grpc server stopped", "error", err)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, c.GetGRPCConfig().GetShutdownTimeout())
+			defer cancel()
+			done := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(done)
+			}()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				server.Stop()
+				return ctx.Err()
+			}
+		},
+	})
+}
+
+func startAuctionWorker(lc fx.Lifecycle, w *auction.Worker) {
+	lc.Append(fx.Hook{
+		OnStart: w.Start,
+		OnStop:  w.Stop,
+	})
+}
+
+func startDeadlineWorker(lc fx.Lifecycle, w *notifications.DeadlineWorker) {
+	lc.Append(fx.Hook{
+		OnStart: w.Start,
+		OnStop:  w.Stop,
+	})
+}
+
+func connectDB(c *config.Config) (*sql.DB, error) {
+	return db.ConnectDB(c.GetDBConfig())
+}
+
+func startIdempotencyCleanup(lc fx.Lifecycle, st *idempotency.Store) {
+	lc.Append(fx.Hook{
+		OnStart: st.Start,
+		OnStop:  st.Stop,
+	})
+}
+
+func closeDB(lc fx.Lifecycle, conn *sql.DB) {
+	lc.Append(fx.Hook{
+		OnStop: func(context.Context) error {
+			return conn.Close()
+		},
+	})
+}
+
+// closeEventsHub ends open SSE streams, so it has to run before the HTTP server
+// waits for in-flight requests.
+func closeEventsHub(lc fx.Lifecycle, hub *events.Hub) {
+	lc.Append(fx.Hook{
+		OnStop: func(context.Context) error {
+			hub.Close()
+			return nil
+		},
+	})
+}
+
+// drainOnShutdown must be invoked last: fx stops hooks in reverse order, so
+// /readyz starts failing before anything else is stopped.
+func drainOnShutdown(lc fx.Lifecycle, checker *health.Checker, c *config.Config) {
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			checker.SetShuttingDown()
+			select {
+			case <-time.After(c.GetHealthConfig().GetShutdownDelay()):
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+}
+
+// stopMargin covers the stop hooks without their own timeout in config:
+// workers, pending notifications and the DB pool.
+const stopMargin = 30 * time.Second
+
+// stopTimeout gives the stop hooks, which run one after another, time to
+// drain readiness and both servers in full.
+func stopTimeout(cfg *config.Config) time.Duration {
+	return cfg.GetHealthConfig().GetShutdownDelay() +
+		cfg.GetHTTPConfig().GetShutdownTimeout() +
+		cfg.GetGRPCConfig().GetShutdownTimeout() +
+		stopMargin
+}
+
+func BuildServerAndEnv(cfg *config.Config) *fx.App {
 	return fx.New(
+		fx.Supply(cfg),
 		fx.Provide(
-			config.NewConfig,
+			connectDB,
+			logging.NewLogger,
+			metrics.NewMetrics,
+			tracing.NewTracing,
+			ratelimit.NewLimiter,
+			idempotency.NewStore,
+			events.NewHub,
 			storage.NewStorage,
+			conflict.NewChecker,
+			auction.NewWorker,
+			fx.Annotate(notifications.NewSMTPSender, fx.As(new(notifications.Sender))),
+			notifications.NewService,
+			notifications.NewDeadlineWorker,
+			service.NewTenderService,
+			service.NewBidService,
+			service.NewOrganizationService,
+			service.NewQualificationService,
+			service.NewContractService,
+			service.NewNotificationService,
+			health.NewChecker,
 			handlers.NewHandlers,
+			grpcapi.NewServer,
+		),
+		fx.WithLogger(func(l *slog.Logger) fxevent.Logger {
+			return &fxevent.SlogLogger{Logger: l}
+		}),
+		// fx stops hooks in reverse order: readiness goes down first, then
+		// servers drain, then workers stop and finally the DB pool is closed.
+		fx.Invoke(
+			closeDB,
+			startDeadlineWorker,
+			startAuctionWorker,
+			startIdempotencyCleanup,
+			startGRPCServer,
+			buildFiberServer,
+			closeEventsHub,
+			drainOnShutdown,
 		),
-		fx.Invoke(buildFiberServer),
+		fx.StopTimeout(stopTimeout(cfg)),
 	)
 }
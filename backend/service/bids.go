@@ -0,0 +1,588 @@
+package service
+
+import (
+	"backend/auction"
+	"backend/conflict"
+	"backend/entities"
+	"backend/entities/auction_status"
+	"backend/entities/author_type"
+	"backend/entities/bid_status"
+	"backend/entities/decision"
+	"backend/entities/tender_status"
+	"backend/metrics"
+	"backend/notifications"
+	"backend/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"time"
+)
+
+type BidService struct {
+	s             *storage.Storage
+	conflicts     *conflict.Checker
+	notifications *notifications.Service
+	metrics       *metrics.Metrics
+	validator     *validator.Validate
+}
+
+func NewBidService(
+	s *storage.Storage,
+	conflicts *conflict.Checker,
+	n *notifications.Service,
+	m *metrics.Metrics,
+) *BidService {
+	return &BidService{s: s, conflicts: conflicts, notifications: n, metrics: m, validator: NewValidator()}
+}
+
+type CreateBidParams struct {
+	Name        string   `json:"name" validate:"required,max=50"`
+	Description string   `json:"description" validate:"required,max=1000,min=1"`
+	TenderId    string   `json:"tenderId" validate:"required,uid"`
+	AuthorType  string   `json:"authorType" validate:"required,oneof=User Organization"`
+	AuthorId    string   `json:"authorId" validate:"required,uid"`
+	Amount      *float64 `json:"amount,omitempty" validate:"omitempty,gt=0"`
+}
+
+func (b BidService) Create(ctx context.Context, username string, params CreateBidParams) (entities.Bid, error) {
+	if err := validate(b.validator, params); err != nil {
+		return entities.Bid{}, err
+	}
+	userId, err := getUserId(ctx, b.s, username)
+	if err != nil {
+		return entities.Bid{}, err
+	}
+	if params.AuthorType == author_type.ORGANIZATION {
+		if _, err := getOrganization(ctx, b.s, params.AuthorId); err != nil {
+			return entities.Bid{}, err
+		}
+		reason := "User has no permission to bid on behalf of this organization"
+		if err := checkResponsible(ctx, b.s, userId, params.AuthorId, reason); err != nil {
+			return entities.Bid{}, err
+		}
+	} else {
+		if _, err := getUser(ctx, b.s, params.AuthorId); err != nil {
+			return entities.Bid{}, err
+		}
+		if params.AuthorId != userId {
+			return entities.Bid{}, NewError(CodePermissionDenied, "User can only bid on their own behalf")
+		}
+	}
+	tender, err := getTender(ctx, b.s, params.TenderId)
+	if err != nil {
+		return entities.Bid{}, err
+	}
+	conflictResult, err := b.conflicts.CheckBid(ctx, tender, params.AuthorType, params.AuthorId)
+	if err != nil {
+		return entities.Bid{}, err
+	}
+	if conflictResult.Blocked {
+		return entities.Bid{}, NewError(CodeConflictOfInterest, conflictResult.Reason)
+	}
+	if err := b.checkBlocked(ctx, tender.OrganizationId, userId, params.AuthorType, params.AuthorId); err != nil {
+		return entities.Bid{}, err
+	}
+	if tender.RequireQualification {
+		for _, serviceType := range tender.ServiceType {
+			qualified, err := b.s.HasValidQualification(ctx, params.AuthorType, params.AuthorId, tender.OrganizationId, serviceType)
+			if err != nil {
+				return entities.Bid{}, err
+			}
+			if !qualified {
+				return entities.Bid{}, NewError(CodeQualificationRequired, "No valid qualification for "+serviceType)
+			}
+		}
+	}
+	now := time.Now().UTC()
+	if auction.Status(tender, now) == auction_status.FINISHED {
+		return entities.Bid{}, NewError(CodeAuctionFinished, "")
+	}
+	overBudget := isOverBudget(tender, params.Amount)
+	if overBudget && tender.RejectOverBudget {
+		return entities.Bid{}, NewError(CodeBidOverBudget, "")
+	}
+	if err := b.checkBestOffer(ctx, tender, params.AuthorType, params.AuthorId, "", params.Amount, now); err != nil {
+		return entities.Bid{}, err
+	}
+	bid, err := b.s.CreateBid(ctx, entities.Bid{
+		TenderId:           params.TenderId,
+		Name:               params.Name,
+		Description:        params.Description,
+		AuthorType:         params.AuthorType,
+		AuthorId:           params.AuthorId,
+		ConflictOfInterest: conflictResult.Conflict,
+		Amount:             params.Amount,
+	})
+	if err != nil {
+		return entities.Bid{}, err
+	}
+	bid.OverBudget = overBudget
+	b.metrics.BidSubmitted()
+	b.notifications.BidCreated(ctx, tender, bid)
+	return bid, nil
+}
+
+type FilterBidsParams struct {
+	Limit    int    `json:"limit" validate:"min=0"`
+	Offset   int    `json:"offset" validate:"min=0"`
+	Username string `json:"username" validate:"max=50"`
+}
+
+func (b BidService) FilterMy(ctx context.Context, params FilterBidsParams) ([]entities.Bid, error) {
+	if err := validate(b.validator, params); err != nil {
+		return nil, err
+	}
+	userId, err := getUserId(ctx, b.s, params.Username)
+	if err != nil {
+		return nil, err
+	}
+	return b.s.GetMyBids(ctx, userId, params.Limit, params.Offset)
+}
+
+type FilterBidsByTenderParams struct {
+	Limit            int    `json:"limit" validate:"min=0"`
+	Offset           int    `json:"offset" validate:"min=0"`
+	Username         string `json:"username" validate:"required,max=50"`
+	IncludeWithdrawn bool   `json:"includeWithdrawn"`
+}
+
+func (b BidService) FilterByTender(ctx context.Context, tenderId string, params FilterBidsByTenderParams) ([]entities.Bid, error) {
+	if err := validate(b.validator, params); err != nil {
+		return nil, err
+	}
+	userId, err := getUserId(ctx, b.s, params.Username)
+	if err != nil {
+		return nil, err
+	}
+	tender, err := getTender(ctx, b.s, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponsible(ctx, b.s, userId, tender.OrganizationId, "User has no permission to see these bids"); err != nil {
+		return nil, err
+	}
+	bids, err := b.s.GetBidsByTender(ctx, tenderId, params.Limit, params.Offset, params.IncludeWithdrawn)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bids {
+		bids[i].OverBudget = isOverBudget(tender, bids[i].Amount)
+	}
+	return bids, nil
+}
+
+func (b BidService) GetStatus(ctx context.Context, bidId string, username string) (entities.Status, error) {
+	bid, _, _, _, err := b.access(ctx, bidId, username)
+	if err != nil {
+		return entities.Status{}, err
+	}
+	return entities.Status{Status: bid.Status, Version: bid.Version, UpdatedAt: bid.UpdatedAt}, nil
+}
+
+func (b BidService) ChangeStatus(ctx context.Context, bidId string, username string, status string) (entities.Bid, error) {
+	if err := validateVar(b.validator, "status", status, "required,oneof=Created Published Cancelled"); err != nil {
+		return entities.Bid{}, err
+	}
+	bid, tender, isOwner, isAuthor, err := b.access(ctx, bidId, username)
+	if err != nil {
+		return entities.Bid{}, err
+	}
+	if err := checkBidStatusChange(bid, tender, isOwner, isAuthor, status); err != nil {
+		return entities.Bid{}, err
+	}
+	now := time.Now().UTC()
+	publishing := status == bid_status.PUBLISHED && bid.Status != bid_status.PUBLISHED
+	if publishing {
+		if err := b.checkPublication(ctx, tender, bid, bid.Amount, now); err != nil {
+			return entities.Bid{}, err
+		}
+	}
+	rejected := isAutoRejected(tender, status, bid.Amount)
+	if rejected {
+		status = bid_status.CLOSED
+	}
+	var bidNew entities.Bid
+	err = b.s.WithTx(ctx, func(tx *storage.Storage) error {
+		var err error
+		if bidNew, err = tx.PatchBid(ctx, bidId, storage.BidPatch{Status: &status}); err != nil {
+			return err
+		}
+		if publishing && !rejected && bid.Amount != nil {
+			if err := extendAuction(ctx, tx, tender, now); err != nil {
+				return err
+			}
+		}
+		if rejected {
+			return tx.SetDecision(ctx, bidId, nil, decision.REJECTED, false)
+		}
+		return nil
+	})
+	if err != nil {
+		return entities.Bid{}, err
+	}
+	bidNew.OverBudget = isOverBudget(tender, bidNew.Amount)
+	if rejected {
+		b.metrics.DecisionMade(decision.REJECTED)
+		b.notifications.DecisionMade(ctx, tender, bidNew, decision.REJECTED)
+	}
+	return bidNew, nil
+}
+
+type EditBidParams struct {
+	Name        string   `json:"name,omitempty" validate:"omitempty,max=50"`
+	Description string   `json:"description,omitempty" validate:"omitempty,max=1000,min=1"`
+	Status      string   `json:"status,omitempty" validate:"omitempty,oneof=Created Published Closed"`
+	Amount      *float64 `json:"amount,omitempty" validate:"omitempty,gt=0"`
+}
+
+func (b BidService) Edit(ctx context.Context, bidId string, username string, params EditBidParams) (entities.Bid, error) {
+	if err := validate(b.validator, params); err != nil {
+		return entities.Bid{}, err
+	}
+	bid, tender, isOwner, isAuthor, err := b.access(ctx, bidId, username)
+	if err != nil {
+		return entities.Bid{}, err
+	}
+
+	now := time.Now().UTC()
+	if params.Amount != nil {
+		if err := checkAuctionOffer(tender, bid, *params.Amount, now); err != nil {
+			return entities.Bid{}, err
+		}
+	}
+	patch := storage.BidPatch{Amount: params.Amount}
+	if len(params.Name) > 0 {
+		patch.Name = &params.Name
+	}
+	if len(params.Description) > 0 {
+		patch.Description = &params.Description
+	}
+	status := bid.Status
+	if len(params.Status) > 0 {
+		status = params.Status
+		patch.Status = &params.Status
+		if err := checkBidStatusChange(bid, tender, isOwner, isAuthor, params.Status); err != nil {
+			return entities.Bid{}, err
+		}
+	}
+	amount := bid.Amount
+	if params.Amount != nil {
+		amount = params.Amount
+	}
+	newOffer := status == bid_status.PUBLISHED && (bid.Status != bid_status.PUBLISHED || params.Amount != nil)
+	if newOffer {
+		if err := b.checkPublication(ctx, tender, bid, amount, now); err != nil {
+			return entities.Bid{}, err
+		}
+	}
+	rejected := isAutoRejected(tender, status, amount)
+	if rejected {
+		closed := bid_status.CLOSED
+		patch.Status = &closed
+	}
+	var newBid entities.Bid
+	err = b.s.WithTx(ctx, func(tx *storage.Storage) error {
+		var err error
+		if newBid, err = tx.PatchBid(ctx, bidId, patch); err != nil {
+			return err
+		}
+		if newOffer && !rejected && amount != nil {
+			if err := extendAuction(ctx, tx, tender, now); err != nil {
+				return err
+			}
+		}
+		if rejected {
+			return tx.SetDecision(ctx, bidId, nil, decision.REJECTED, false)
+		}
+		return nil
+	})
+	if err != nil {
+		return entities.Bid{}, err
+	}
+	newBid.OverBudget = isOverBudget(tender, newBid.Amount)
+	if rejected {
+		b.metrics.DecisionMade(decision.REJECTED)
+		b.notifications.DecisionMade(ctx, tender, newBid, decision.REJECTED)
+	}
+	return newBid, nil
+}
+
+func (b BidService) Withdraw(ctx context.Context, bidId string, username string, reason string) (entities.Bid, error) {
+	if err := validateVar(b.validator, "reason", reason, "required,max=1000"); err != nil {
+		return entities.Bid{}, err
+	}
+	userId, err := getUserId(ctx, b.s, username)
+	if err != nil {
+		return entities.Bid{}, err
+	}
+	var withdrawn entities.Bid
+	err = b.s.WithTx(ctx, func(tx *storage.Storage) error {
+		bid, err := getBid(ctx, tx, bidId)
+		if err != nil {
+			return err
+		}
+		isAuthor, err := isAuthor(ctx, tx, userId, bid.AuthorType, bid.AuthorId)
+		if err != nil {
+			return err
+		}
+		if !isAuthor {
+			return NewError(CodePermissionDenied, "Only the author can withdraw this bid")
+		}
+		if bid.Status != bid_status.CREATED && bid.Status != bid_status.PUBLISHED {
+			return NewError(CodeBidStatusConflict, "Bid in status "+bid.Status+" can't be withdrawn")
+		}
+		withdrawn, err = tx.WithdrawBid(ctx, bidId, userId, reason)
+		return err
+	})
+	if err != nil {
+		return entities.Bid{}, err
+	}
+	return withdrawn, nil
+}
+
+func (b BidService) SetDecision(ctx context.Context, bidId string, username string, verdict string) (entities.Tender, error) {
+	if err := validateVar(b.validator, "decision", verdict, "oneof=Approved Rejected"); err != nil {
+		return entities.Tender{}, err
+	}
+	bid, err := getBid(ctx, b.s, bidId)
+	if err != nil {
+		return entities.Tender{}, err
+	}
+	userId, err := getUserId(ctx, b.s, username)
+	if err != nil {
+		return entities.Tender{}, err
+	}
+
+	tender, err := b.s.GetTender(ctx, bid.TenderId)
+	if err != nil {
+		return entities.Tender{}, err
+	}
+	if err := checkResponsible(ctx, b.s, userId, tender.OrganizationId, "User has no permission to see this bid"); err != nil {
+		return entities.Tender{}, err
+	}
+
+	conflictResult, err := b.conflicts.CheckDecision(ctx, userId, tender, bid)
+	if err != nil {
+		return entities.Tender{}, err
+	}
+	if conflictResult.Blocked {
+		return entities.Tender{}, NewError(CodeConflictOfInterest, conflictResult.Reason)
+	}
+
+	var tenderNew entities.Tender
+	err = b.s.WithTx(ctx, func(tx *storage.Storage) error {
+		var err error
+		if tenderNew, err = checkDecisionAllowed(ctx, tx, bidId, verdict); err != nil {
+			return err
+		}
+		if err := tx.SetDecision(ctx, bidId, &userId, verdict, conflictResult.Conflict); err != nil {
+			return err
+		}
+		if verdict != decision.APPROVED {
+			return nil
+		}
+		if tenderNew.Status != tender_status.CLOSED {
+			newStatus := tender_status.CLOSED
+			if tenderNew, err = tx.PatchTender(ctx, tender.Id, storage.TenderPatch{Status: &newStatus}); err != nil {
+				return err
+			}
+		}
+		_, err = tx.CreateContract(ctx, tenderNew, bid)
+		return err
+	})
+	if err != nil {
+		return entities.Tender{}, err
+	}
+	b.metrics.DecisionMade(verdict)
+	b.notifications.DecisionMade(ctx, tenderNew, bid, verdict)
+	return tenderNew, nil
+}
+
+// checkDecisionAllowed rereads the bid and its tender inside the decision
+// transaction, so that concurrent decisions can't both pass. Decisions are
+// allowed until an approval gives the tender a contract. The tender may
+// already be closed, e.g. when its auction has ended.
+func checkDecisionAllowed(ctx context.Context, tx *storage.Storage, bidId string, verdict string) (entities.Tender, error) {
+	bid, err := getBid(ctx, tx, bidId)
+	if err != nil {
+		return entities.Tender{}, err
+	}
+	if bid.Status != bid_status.PUBLISHED {
+		return entities.Tender{}, NewError(CodeBidStatusConflict, "Decision can only be made on a published bid, this one is "+bid.Status)
+	}
+	tender, err := getTender(ctx, tx, bid.TenderId)
+	if err != nil {
+		return entities.Tender{}, err
+	}
+	if tender.Status == tender_status.CREATED {
+		return entities.Tender{}, NewError(CodeTenderStatusConflict, "Tender is not published")
+	}
+	if verdict == decision.APPROVED && tender.RejectOverBudget && isOverBudget(tender, bid.Amount) {
+		return entities.Tender{}, NewError(CodeBidOverBudget, "Bid can't be approved")
+	}
+	hasContract, err := tx.HasContract(ctx, tender.Id)
+	if err != nil {
+		return entities.Tender{}, err
+	}
+	if hasContract {
+		return entities.Tender{}, NewError(CodeTenderStatusConflict, "Tender already has a contract")
+	}
+	return tender, nil
+}
+
+func (b BidService) GetDecision(ctx context.Context, bidId string, username string) (entities.Decision, error) {
+	bid, _, _, _, err := b.access(ctx, bidId, username)
+	if err != nil {
+		return entities.Decision{}, err
+	}
+	votes, err := b.s.GetDecisionVotes(ctx, bid.Id)
+	if err != nil {
+		return entities.Decision{}, err
+	}
+	result := entities.Decision{Status: decision.UNKNOWN, Version: bid.Version, UpdatedAt: bid.UpdatedAt, Votes: votes}
+	if len(votes) > 0 {
+		result.Status = votes[0].Decision
+		result.UpdatedAt = votes[len(votes)-1].CreatedAt
+	}
+	return result, nil
+}
+
+func (b BidService) access(ctx context.Context, bidId string, username string) (entities.Bid, entities.Tender, bool, bool, error) {
+	userId, err := getUserId(ctx, b.s, username)
+	if err != nil {
+		return entities.Bid{}, entities.Tender{}, false, false, err
+	}
+	bid, err := getBid(ctx, b.s, bidId)
+	if err != nil {
+		return entities.Bid{}, entities.Tender{}, false, false, err
+	}
+	tender, err := b.s.GetTender(ctx, bid.TenderId)
+	if err != nil {
+		return entities.Bid{}, entities.Tender{}, false, false, err
+	}
+	isOwner, err := b.s.CheckOrganizationResponsible(ctx, userId, tender.OrganizationId)
+	if err != nil {
+		return entities.Bid{}, entities.Tender{}, false, false, err
+	}
+	isAuthor, err := isAuthor(ctx, b.s, userId, bid.AuthorType, bid.AuthorId)
+	if err != nil {
+		return entities.Bid{}, entities.Tender{}, false, false, err
+	}
+	if !isOwner && !isAuthor {
+		return entities.Bid{}, entities.Tender{}, false, false, NewError(CodePermissionDenied, "User has no permission to see this bid")
+	}
+	return bid, tender, isOwner, isAuthor, nil
+}
+
+func checkBidStatusChange(bid entities.Bid, tender entities.Tender, isOwner bool, isAuthor bool, status string) error {
+	if bid.Status == bid_status.CLOSED && status != bid_status.CLOSED && tender.RejectOverBudget &&
+		isOverBudget(tender, bid.Amount) {
+		return NewError(CodeBidOverBudget, "Bid was rejected as over budget and can't be reopened")
+	}
+	if status == bid_status.CANCELLED && !isOwner {
+		return NewError(CodePermissionDenied, "Only tender responsibles can cancel a bid, its author has to withdraw it")
+	}
+	if bid.Status == bid_status.WITHDRAWN && status != bid_status.WITHDRAWN {
+		if !isAuthor {
+			return NewError(CodePermissionDenied, "Only the author can resubmit a withdrawn bid")
+		}
+		if tender.Deadline != nil && time.Now().UTC().After(*tender.Deadline) {
+			return NewError(CodeDeadlinePassed, "Withdrawn bid can't be resubmitted")
+		}
+	}
+	return nil
+}
+
+// checkBlocked looks up the blocklist of the tender's organization for the
+// requesting user, the author and everyone behind them: the responsibles of an
+// organization author and the organizations a user is responsible for.
+func (b BidService) checkBlocked(
+	ctx context.Context,
+	organizationId string,
+	userId string,
+	authorType string,
+	authorId string,
+) error {
+	userIds := []string{userId}
+	organizationIds := make([]string, 0)
+	if authorType == author_type.ORGANIZATION {
+		responsibleIds, err := b.s.GetOrganizationResponsibleIds(ctx, authorId)
+		if err != nil {
+			return err
+		}
+		userIds = append(userIds, responsibleIds...)
+		organizationIds = append(organizationIds, authorId)
+	}
+	for _, id := range userIds {
+		ids, err := b.s.GetUserOrganizationIds(ctx, id)
+		if err != nil {
+			return err
+		}
+		organizationIds = append(organizationIds, ids...)
+	}
+	block, err := b.s.GetActiveBlock(ctx, organizationId, userIds, organizationIds)
+	if err == nil {
+		return NewError(CodeSupplierBlocked, block.Reason)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+func isOverBudget(tender entities.Tender, amount *float64) bool {
+	return tender.Budget != nil && amount != nil && *amount > *tender.Budget
+}
+
+// isAutoRejected reports whether a bid that is about to get status and amount
+// has to be rejected instead. Such a bid is closed with a single Rejected
+// decision and can't be reopened, so the rejection happens once.
+func isAutoRejected(tender entities.Tender, status string, amount *float64) bool {
+	return status == bid_status.PUBLISHED && tender.RejectOverBudget && isOverBudget(tender, amount)
+}
+
+func checkAuctionOffer(tender entities.Tender, bid entities.Bid, amount float64, now time.Time) error {
+	switch auction.Status(tender, now) {
+	case auction_status.FINISHED:
+		return NewError(CodeAuctionFinished, "Price can't be changed")
+	case auction_status.ACTIVE:
+		if bid.Amount != nil && amount >= *bid.Amount {
+			return NewError(CodeAuctionPriceNotLowered, "")
+		}
+	}
+	return nil
+}
+
+// checkPublication is called when a bid puts a new offer into the auction:
+// it is published or its published price is changed.
+func (b BidService) checkPublication(ctx context.Context, tender entities.Tender, bid entities.Bid, amount *float64, now time.Time) error {
+	if auction.Status(tender, now) == auction_status.FINISHED {
+		return NewError(CodeAuctionFinished, "Bid can't be published")
+	}
+	return b.checkBestOffer(ctx, tender, bid.AuthorType, bid.AuthorId, bid.Id, amount, now)
+}
+
+// checkBestOffer keeps the author's offers in an active auction going down
+// across all of their bids, not only within one bid.
+func (b BidService) checkBestOffer(ctx context.Context, tender entities.Tender, authorType string, authorId string, bidId string, amount *float64, now time.Time) error {
+	if amount == nil || auction.Status(tender, now) != auction_status.ACTIVE {
+		return nil
+	}
+	best, err := b.s.GetBestOffer(ctx, tender.Id, authorType, authorId, bidId)
+	if err != nil {
+		return err
+	}
+	if best != nil && *amount >= *best {
+		return NewError(CodeAuctionPriceNotLowered, "Offer must be lower than your best published offer")
+	}
+	return nil
+}
+
+func extendAuction(ctx context.Context, s *storage.Storage, tender entities.Tender, now time.Time) error {
+	endsAt, ok := auction.ExtendedEnd(tender, now)
+	if !ok {
+		return nil
+	}
+	return s.ExtendAuction(ctx, tender.Id, endsAt)
+}
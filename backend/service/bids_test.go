@@ -0,0 +1,258 @@
+package service
+
+import (
+	"backend/entities"
+	"backend/entities/author_type"
+	"backend/entities/bid_status"
+	"backend/entities/decision"
+	"backend/entities/tender_status"
+	"backend/storage"
+	"context"
+	"testing"
+	"time"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestOverBudgetBidIsRejectedOnce(t *testing.T) {
+	tests := []struct {
+		name    string
+		publish func(b *BidService, bid entities.Bid) (entities.Bid, error)
+	}{
+		{
+			name: "status change",
+			publish: func(b *BidService, bid entities.Bid) (entities.Bid, error) {
+				return b.ChangeStatus(context.Background(), bid.Id, "supplier", bid_status.PUBLISHED)
+			},
+		},
+		{
+			name: "edit",
+			publish: func(b *BidService, bid entities.Bid) (entities.Bid, error) {
+				return b.Edit(context.Background(), bid.Id, "supplier", EditBidParams{Status: bid_status.PUBLISHED})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newTestEnv(t)
+			ownerId := env.user(t, "owner")
+			supplierId := env.user(t, "supplier")
+			buyerId := env.organization(t, ownerId)
+			params := tenderParams(buyerId)
+			params.Budget = ptr(100.0)
+			params.Currency = ptr("RUB")
+			params.RejectOverBudget = true
+			tender := env.publishedTender(t, "owner", params)
+			bids := env.bids(t)
+			bidParams := CreateBidParams{
+				Name:        "Bid",
+				Description: "Bid",
+				TenderId:    tender.Id,
+				AuthorType:  author_type.USER,
+				AuthorId:    supplierId,
+				Amount:      ptr(150.0),
+			}
+			_, err := bids.Create(ctx, "supplier", bidParams)
+			wantCode(t, err, CodeBidOverBudget)
+
+			bidParams.Amount = ptr(50.0)
+			bid, err := bids.Create(ctx, "supplier", bidParams)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := env.tenders().Edit(ctx, tender.Id, "owner", EditTenderParams{Budget: ptr(40.0)}); err != nil {
+				t.Fatal(err)
+			}
+
+			bid, err = tt.publish(bids, bid)
+			if err != nil {
+				t.Fatalf("publish: %v", err)
+			}
+			if bid.Status != bid_status.CLOSED || !bid.OverBudget {
+				t.Errorf("bid status = %s, over budget = %v, want closed over budget bid", bid.Status, bid.OverBudget)
+			}
+			stored, err := env.s.GetBid(ctx, bid.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.Status != bid_status.CLOSED {
+				t.Errorf("stored bid status = %s, want %s", stored.Status, bid_status.CLOSED)
+			}
+
+			_, err = tt.publish(bids, bid)
+			wantCode(t, err, CodeBidOverBudget)
+			_, err = bids.SetDecision(ctx, bid.Id, "owner", decision.APPROVED)
+			wantCode(t, err, CodeBidStatusConflict)
+
+			votes, err := env.s.GetDecisionVotes(ctx, bid.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(votes) != 1 || votes[0].Decision != decision.REJECTED || votes[0].UserId != nil {
+				t.Errorf("votes = %+v, want a single automatic rejection", votes)
+			}
+		})
+	}
+}
+
+func TestDecisions(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	ownerId := env.user(t, "owner")
+	firstId := env.user(t, "first")
+	secondId := env.user(t, "second")
+	buyerId := env.organization(t, ownerId)
+	bids := env.bids(t)
+
+	tender := env.publishedTender(t, "owner", tenderParams(buyerId))
+	first := publishedBid(t, bids, "first", firstId, tender.Id, nil)
+	second := publishedBid(t, bids, "second", secondId, tender.Id, nil)
+
+	tender, err := bids.SetDecision(ctx, first.Id, "owner", decision.REJECTED)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tender.Status != tender_status.PUBLISHED {
+		t.Errorf("tender status after rejection = %s, want %s", tender.Status, tender_status.PUBLISHED)
+	}
+	tender, err = bids.SetDecision(ctx, second.Id, "owner", decision.APPROVED)
+	if err != nil {
+		t.Fatalf("approval after rejection: %v", err)
+	}
+	if tender.Status != tender_status.CLOSED {
+		t.Errorf("tender status after approval = %s, want %s", tender.Status, tender_status.CLOSED)
+	}
+	_, err = bids.SetDecision(ctx, first.Id, "owner", decision.APPROVED)
+	wantCode(t, err, CodeTenderStatusConflict)
+
+	now := time.Now().UTC()
+	params := tenderParams(buyerId)
+	params.AuctionStartsAt = ptr(now.Add(-time.Hour))
+	params.AuctionEndsAt = ptr(now.Add(time.Hour))
+	auctionTender := env.publishedTender(t, "owner", params)
+	offer := publishedBid(t, bids, "first", firstId, auctionTender.Id, ptr(100.0))
+	if err := env.s.FinishAuction(ctx, auctionTender.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bids.SetDecision(ctx, offer.Id, "owner", decision.APPROVED); err != nil {
+		t.Fatalf("approval after auction: %v", err)
+	}
+	if hasContract, err := env.s.HasContract(ctx, auctionTender.Id); err != nil || !hasContract {
+		t.Errorf("auction tender has contract = %v, %v, want true", hasContract, err)
+	}
+}
+
+func TestCreateBidChecksCaller(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	ownerId := env.user(t, "owner")
+	supplierId := env.user(t, "supplier")
+	buyerId := env.organization(t, ownerId)
+	tender := env.publishedTender(t, "owner", tenderParams(buyerId))
+	bids := env.bids(t)
+	tests := []struct {
+		name     string
+		username string
+		authorId string
+		want     *Code
+	}{
+		{"bid on behalf of another user", "owner", supplierId, CodePermissionDenied},
+		{"bid of a buyer responsible", "owner", ownerId, CodeConflictOfInterest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bids.Create(ctx, tt.username, CreateBidParams{
+				Name:        "Bid",
+				Description: "Bid",
+				TenderId:    tender.Id,
+				AuthorType:  author_type.USER,
+				AuthorId:    tt.authorId,
+			})
+			wantCode(t, err, tt.want)
+		})
+	}
+}
+
+func TestAuctionOffers(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	ownerId := env.user(t, "owner")
+	supplierId := env.user(t, "supplier")
+	buyerId := env.organization(t, ownerId)
+	now := time.Now().UTC()
+	params := tenderParams(buyerId)
+	params.AuctionStartsAt = ptr(now.Add(-time.Hour))
+	params.AuctionEndsAt = ptr(now.Add(30 * time.Minute))
+	params.AuctionExtension = 3600
+	tender := env.publishedTender(t, "owner", params)
+	bids := env.bids(t)
+	bidParams := CreateBidParams{
+		Name:        "Bid",
+		Description: "Bid",
+		TenderId:    tender.Id,
+		AuthorType:  author_type.USER,
+		AuthorId:    supplierId,
+	}
+	endsAt := func() time.Time {
+		t.Helper()
+		stored, err := env.s.GetTender(ctx, tender.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return *stored.AuctionEndsAt
+	}
+
+	first := publishedBid(t, bids, "supplier", supplierId, tender.Id, ptr(100.0))
+	extendedEnd := endsAt()
+	if !extendedEnd.After(*tender.AuctionEndsAt) {
+		t.Fatalf("auction end = %v after publishing, want it extended past %v", extendedEnd, *tender.AuctionEndsAt)
+	}
+
+	bidParams.Amount = ptr(120.0)
+	_, err := bids.Create(ctx, "supplier", bidParams)
+	wantCode(t, err, CodeAuctionPriceNotLowered)
+
+	bidParams.Amount = ptr(90.0)
+	second, err := bids.Create(ctx, "supplier", bidParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := endsAt(); !got.Equal(extendedEnd) {
+		t.Errorf("auction end = %v after creating an unpublished bid, want %v", got, extendedEnd)
+	}
+	if _, err := bids.Edit(ctx, first.Id, "supplier", EditBidParams{Amount: ptr(80.0)}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = bids.ChangeStatus(ctx, second.Id, "supplier", bid_status.PUBLISHED)
+	wantCode(t, err, CodeAuctionPriceNotLowered)
+
+	if _, err := env.s.PatchTender(ctx, tender.Id, storage.TenderPatch{AuctionEndsAt: ptr(now.Add(-time.Minute))}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = bids.Edit(ctx, second.Id, "supplier", EditBidParams{Status: bid_status.PUBLISHED})
+	wantCode(t, err, CodeAuctionFinished)
+
+	if err := env.s.FinishAuction(ctx, tender.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bids.Withdraw(ctx, first.Id, "supplier", "changed my mind"); err != nil {
+		t.Fatal(err)
+	}
+	result, err := env.tenders().GetAuction(ctx, tender.Id, "owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Ranking) != 1 || result.Ranking[0].BidId != first.Id || result.Ranking[0].Rank != 1 {
+		t.Errorf("ranking = %+v, want the stored ranking with bid %s first", result.Ranking, first.Id)
+	}
+}
+
+func TestMalformedIdIsInvalidParams(t *testing.T) {
+	env := newTestEnv(t)
+	env.user(t, "supplier")
+	_, err := env.bids(t).GetStatus(context.Background(), "not-a-uuid", "supplier")
+	wantCode(t, err, CodeInvalidParams)
+}
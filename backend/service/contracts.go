@@ -0,0 +1,121 @@
+package service
+
+import (
+	"backend/entities"
+	"backend/entities/contract_status"
+	"backend/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	buyerParty    = "buyer"
+	supplierParty = "supplier"
+)
+
+// contractTransitions maps every allowed transition to the party that owns it:
+// the supplier signs or declines the awarded draft, the buyer accepts the work
+// or terminates the signed contract.
+var contractTransitions = map[string]map[string]string{
+	contract_status.DRAFT: {
+		contract_status.SIGNED:     supplierParty,
+		contract_status.TERMINATED: supplierParty,
+	},
+	contract_status.SIGNED: {
+		contract_status.COMPLETED:  buyerParty,
+		contract_status.TERMINATED: buyerParty,
+	},
+}
+
+type ContractService struct {
+	s         *storage.Storage
+	validator *validator.Validate
+}
+
+func NewContractService(s *storage.Storage) *ContractService {
+	return &ContractService{s: s, validator: NewValidator()}
+}
+
+type FilterContractsParams struct {
+	Limit    int    `json:"limit" validate:"min=0"`
+	Offset   int    `json:"offset" validate:"min=0"`
+	Username string `json:"username" validate:"required,max=50"`
+}
+
+func (c ContractService) FilterMy(ctx context.Context, params FilterContractsParams) ([]entities.Contract, error) {
+	if err := validate(c.validator, params); err != nil {
+		return nil, err
+	}
+	userId, err := getUserId(ctx, c.s, params.Username)
+	if err != nil {
+		return nil, err
+	}
+	return c.s.GetMyContracts(ctx, userId, params.Limit, params.Offset)
+}
+
+func (c ContractService) Get(ctx context.Context, contractId string, username string) (entities.Contract, error) {
+	contract, _, err := partyContract(ctx, c.s, contractId, username, "User has no permission to see this contract")
+	return contract, err
+}
+
+func (c ContractService) ChangeStatus(ctx context.Context, contractId string, username string, status string) (entities.Contract, error) {
+	tag := "required,oneof=Draft Signed Completed Terminated"
+	if err := validateVar(c.validator, "status", status, tag); err != nil {
+		return entities.Contract{}, err
+	}
+	var contractNew entities.Contract
+	err := c.s.WithTx(ctx, func(tx *storage.Storage) error {
+		contract, parties, err := partyContract(ctx, tx, contractId, username, "User has no permission to change this contract")
+		if err != nil {
+			return err
+		}
+		party, ok := contractTransitions[contract.Status][status]
+		if !ok {
+			return NewError(CodeContractStatusConflict, "Contract can't be moved from "+contract.Status+" to "+status)
+		}
+		if !parties[party] {
+			return NewError(CodePermissionDenied, "Only the "+party+" can move the contract from "+contract.Status+" to "+status)
+		}
+		contractNew, err = tx.SetContractStatus(ctx, contractId, status)
+		return err
+	})
+	if err != nil {
+		return entities.Contract{}, err
+	}
+	return contractNew, nil
+}
+
+// partyContract returns the contract with the parties the user acts for.
+func partyContract(
+	ctx context.Context,
+	s *storage.Storage,
+	contractId string,
+	username string,
+	reason string,
+) (entities.Contract, map[string]bool, error) {
+	userId, err := getUserId(ctx, s, username)
+	if err != nil {
+		return entities.Contract{}, nil, err
+	}
+	contract, err := s.GetContract(ctx, contractId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.Contract{}, nil, NewError(CodeContractNotFound, "")
+		}
+		return entities.Contract{}, nil, err
+	}
+	isBuyer, err := s.CheckOrganizationResponsible(ctx, userId, contract.BuyerOrganizationId)
+	if err != nil {
+		return entities.Contract{}, nil, err
+	}
+	isSupplier, err := isAuthor(ctx, s, userId, contract.SupplierType, contract.SupplierId)
+	if err != nil {
+		return entities.Contract{}, nil, err
+	}
+	if !isBuyer && !isSupplier {
+		return entities.Contract{}, nil, NewError(CodePermissionDenied, reason)
+	}
+	return contract, map[string]bool{buyerParty: isBuyer, supplierParty: isSupplier}, nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"backend/entities/contract_status"
+	"backend/entities/decision"
+	"context"
+	"testing"
+)
+
+func TestContractTransitionsBelongToParties(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	ownerId := env.user(t, "owner")
+	supplierId := env.user(t, "supplier")
+	buyerId := env.organization(t, ownerId)
+	bids := env.bids(t)
+	tender := env.publishedTender(t, "owner", tenderParams(buyerId))
+	bid := publishedBid(t, bids, "supplier", supplierId, tender.Id, nil)
+	if _, err := bids.SetDecision(ctx, bid.Id, "owner", decision.APPROVED); err != nil {
+		t.Fatal(err)
+	}
+	contracts := NewContractService(env.s)
+	mine, err := contracts.FilterMy(ctx, FilterContractsParams{Limit: 1, Username: "supplier"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mine) != 1 {
+		t.Fatalf("supplier has %d contracts, want 1", len(mine))
+	}
+	contractId := mine[0].Id
+
+	steps := []struct {
+		username string
+		status   string
+		wantCode *Code
+	}{
+		{username: "owner", status: contract_status.SIGNED, wantCode: CodePermissionDenied},
+		{username: "supplier", status: contract_status.SIGNED},
+		{username: "supplier", status: contract_status.COMPLETED, wantCode: CodePermissionDenied},
+		{username: "supplier", status: contract_status.TERMINATED, wantCode: CodePermissionDenied},
+		{username: "owner", status: contract_status.COMPLETED},
+		{username: "owner", status: contract_status.TERMINATED, wantCode: CodeContractStatusConflict},
+	}
+	for _, step := range steps {
+		contract, err := contracts.ChangeStatus(ctx, contractId, step.username, step.status)
+		if step.wantCode != nil {
+			wantCode(t, err, step.wantCode)
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s moving to %s: %v", step.username, step.status, err)
+		}
+		if contract.Status != step.status {
+			t.Errorf("contract status = %s, want %s", contract.Status, step.status)
+		}
+	}
+}
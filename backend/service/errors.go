@@ -0,0 +1,150 @@
+package service
+
+import (
+	"backend/entities/language"
+	"backend/storage"
+	"context"
+	"errors"
+	"net/http"
+)
+
+var (
+	ErrInvalid      = errors.New("invalid argument")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrTimeout      = errors.New("timeout")
+	ErrUnavailable  = errors.New("unavailable")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrInternal     = errors.New("internal")
+)
+
+type Code struct {
+	Name     string
+	Kind     error
+	Status   int
+	Messages map[string]string
+}
+
+func newCode(name string, kind error, status int, en string, ru string) *Code {
+	return &Code{
+		Name:     name,
+		Kind:     kind,
+		Status:   status,
+		Messages: map[string]string{language.EN: en, language.RU: ru},
+	}
+}
+
+func (c *Code) Message(lang string) string {
+	if message, ok := c.Messages[lang]; ok {
+		return message
+	}
+	return c.Messages[language.EN]
+}
+
+var (
+	CodeInvalidBody = newCode("INVALID_BODY", ErrInvalid, http.StatusBadRequest,
+		"Wrong format of body", "Неверный формат тела запроса")
+	CodeInvalidQuery = newCode("INVALID_QUERY", ErrInvalid, http.StatusBadRequest,
+		"Wrong format of query", "Неверный формат параметров запроса")
+	CodeInvalidParams = newCode("INVALID_PARAMS", ErrInvalid, http.StatusBadRequest,
+		"Wrong format of params", "Неверные значения параметров")
+	CodeInvalidAuction = newCode("INVALID_AUCTION", ErrInvalid, http.StatusBadRequest,
+		"Auction period is invalid", "Неверный период аукциона")
+	CodeInvalidExpiry = newCode("INVALID_EXPIRY", ErrInvalid, http.StatusBadRequest,
+		"Expiry date must be in the future", "Дата окончания должна быть в будущем")
+	CodeUnauthorized = newCode("UNAUTHORIZED", ErrUnauthorized, http.StatusUnauthorized,
+		"User is not correct", "Пользователь не найден")
+	CodePermissionDenied = newCode("PERMISSION_DENIED", ErrForbidden, http.StatusForbidden,
+		"User has no permission for this action", "У пользователя нет прав на это действие")
+	CodeConflictOfInterest = newCode("CONFLICT_OF_INTEREST", ErrForbidden, http.StatusForbidden,
+		"Conflict of interest", "Конфликт интересов")
+	CodeSupplierBlocked = newCode("SUPPLIER_BLOCKED", ErrForbidden, http.StatusForbidden,
+		"Supplier is blocked by the tender organization", "Поставщик заблокирован организацией тендера")
+	CodeQualificationRequired = newCode("QUALIFICATION_REQUIRED", ErrForbidden, http.StatusForbidden,
+		"Supplier has no valid qualification", "У поставщика нет действующей квалификации")
+	CodeTenderNotFound = newCode("TENDER_NOT_FOUND", ErrNotFound, http.StatusNotFound,
+		"Tender is not found", "Тендер не найден")
+	CodeBidNotFound = newCode("BID_NOT_FOUND", ErrNotFound, http.StatusNotFound,
+		"Bid is not found", "Предложение не найдено")
+	CodeUserNotFound = newCode("USER_NOT_FOUND", ErrNotFound, http.StatusNotFound,
+		"User is not found", "Пользователь не найден")
+	CodeOrganizationNotFound = newCode("ORGANIZATION_NOT_FOUND", ErrNotFound, http.StatusNotFound,
+		"Organization is not found", "Организация не найдена")
+	CodeQualificationNotFound = newCode("QUALIFICATION_NOT_FOUND", ErrNotFound, http.StatusNotFound,
+		"Qualification is not found", "Квалификация не найдена")
+	CodeContractNotFound = newCode("CONTRACT_NOT_FOUND", ErrNotFound, http.StatusNotFound,
+		"Contract is not found", "Контракт не найден")
+	CodeBlocklistEntryNotFound = newCode("BLOCKLIST_ENTRY_NOT_FOUND", ErrNotFound, http.StatusNotFound,
+		"Blocklist entry is not found", "Запись черного списка не найдена")
+	CodeNotificationNotFound = newCode("NOTIFICATION_NOT_FOUND", ErrNotFound, http.StatusNotFound,
+		"Notification is not found", "Уведомление не найдено")
+	CodeAuctionNotFound = newCode("AUCTION_NOT_FOUND", ErrNotFound, http.StatusNotFound,
+		"Tender has no auction", "У тендера нет аукциона")
+	CodeRouteNotFound = newCode("ROUTE_NOT_FOUND", ErrNotFound, http.StatusNotFound,
+		"Route is not found", "Метод не найден")
+	CodeAuctionFinished = newCode("AUCTION_FINISHED", ErrConflict, http.StatusConflict,
+		"Auction of this tender is finished", "Аукцион по тендеру завершен")
+	CodeAuctionPriceNotLowered = newCode("AUCTION_PRICE_NOT_LOWERED", ErrConflict, http.StatusConflict,
+		"During the auction price can only be lowered", "Во время аукциона цену можно только снижать")
+	CodeBidStatusConflict = newCode("BID_STATUS_CONFLICT", ErrConflict, http.StatusConflict,
+		"Bid can't be moved to this status", "Предложение нельзя перевести в этот статус")
+	CodeBidOverBudget = newCode("BID_OVER_BUDGET", ErrConflict, http.StatusConflict,
+		"Bid amount exceeds the tender budget", "Сумма предложения превышает бюджет тендера")
+	CodeTenderStatusConflict = newCode("TENDER_STATUS_CONFLICT", ErrConflict, http.StatusConflict,
+		"Tender is not open for decisions", "По тендеру нельзя принять решение")
+	CodeDeadlinePassed = newCode("DEADLINE_PASSED", ErrConflict, http.StatusConflict,
+		"Tender deadline has passed", "Срок подачи предложений истек")
+	CodeContractStatusConflict = newCode("CONTRACT_STATUS_CONFLICT", ErrConflict, http.StatusConflict,
+		"Contract can't be moved to this status", "Контракт нельзя перевести в этот статус")
+	CodeTimeout = newCode("TIMEOUT", ErrTimeout, http.StatusGatewayTimeout,
+		"Request took too long", "Запрос выполнялся слишком долго")
+	CodeQueryTimeout = newCode("QUERY_TIMEOUT", ErrUnavailable, http.StatusServiceUnavailable,
+		"Database is overloaded, try again later", "База данных перегружена, повторите запрос позже")
+	CodeIdempotencyKeyInUse = newCode("IDEMPOTENCY_KEY_IN_USE", ErrConflict, http.StatusConflict,
+		"Request with this Idempotency-Key is still in progress",
+		"Запрос с таким Idempotency-Key еще выполняется")
+	CodeIdempotencyKeyMismatch = newCode("IDEMPOTENCY_KEY_MISMATCH", ErrInvalid, http.StatusUnprocessableEntity,
+		"Idempotency-Key was already used with a different request",
+		"Idempotency-Key уже использован с другим запросом")
+	CodeRateLimited = newCode("RATE_LIMITED", ErrRateLimited, http.StatusTooManyRequests,
+		"Too many requests, try again later", "Слишком много запросов, повторите позже")
+	CodeInternal = newCode("INTERNAL", ErrInternal, http.StatusInternalServerError,
+		"Internal server error", "Внутренняя ошибка сервера")
+)
+
+type Error struct {
+	Code   *Code
+	Detail string
+}
+
+func (e *Error) Error() string {
+	if len(e.Detail) == 0 {
+		return e.Code.Name
+	}
+	return e.Code.Name + ": " + e.Detail
+}
+
+func (e *Error) Is(target error) bool {
+	return e.Code.Kind == target
+}
+
+func NewError(code *Code, detail string) error {
+	return &Error{Code: code, Detail: detail}
+}
+
+func AsError(err error) (*Error, bool) {
+	var serviceErr *Error
+	switch {
+	case errors.As(err, &serviceErr):
+		return serviceErr, true
+	case errors.Is(err, context.DeadlineExceeded):
+		return &Error{Code: CodeTimeout}, true
+	case storage.IsQueryTimeout(err):
+		return &Error{Code: CodeQueryTimeout}, true
+	case storage.IsInvalidInput(err):
+		return &Error{Code: CodeInvalidParams, Detail: "Malformed identifier or value"}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"backend/config"
+	"backend/entities"
+	"backend/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-playground/validator/v10"
+)
+
+type NotificationService struct {
+	s               *storage.Storage
+	defaultLanguage string
+	validator       *validator.Validate
+}
+
+func NewNotificationService(s *storage.Storage, cfg *config.Config) *NotificationService {
+	return &NotificationService{
+		s:               s,
+		defaultLanguage: cfg.GetNotificationsConfig().GetDefaultLanguage(),
+		validator:       NewValidator(),
+	}
+}
+
+type FilterNotificationsParams struct {
+	Limit      int    `json:"limit" validate:"min=0"`
+	Offset     int    `json:"offset" validate:"min=0"`
+	Username   string `json:"username" validate:"required,max=50"`
+	UnreadOnly bool   `json:"unreadOnly"`
+}
+
+func (n NotificationService) Filter(ctx context.Context, params FilterNotificationsParams) ([]entities.Notification, error) {
+	if err := validate(n.validator, params); err != nil {
+		return nil, err
+	}
+	userId, err := getUserId(ctx, n.s, params.Username)
+	if err != nil {
+		return nil, err
+	}
+	return n.s.GetNotifications(ctx, userId, params.UnreadOnly, params.Limit, params.Offset)
+}
+
+func (n NotificationService) MarkRead(ctx context.Context, notificationId string, username string) error {
+	userId, err := getUserId(ctx, n.s, username)
+	if err != nil {
+		return err
+	}
+	found, err := n.s.MarkNotificationRead(ctx, notificationId, userId)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return NewError(CodeNotificationNotFound, "")
+	}
+	return nil
+}
+
+func (n NotificationService) GetPreference(ctx context.Context, username string) (entities.NotificationPreference, error) {
+	userId, err := getUserId(ctx, n.s, username)
+	if err != nil {
+		return entities.NotificationPreference{}, err
+	}
+	preference, err := n.s.GetNotificationPreference(ctx, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.NotificationPreference{
+				UserId:       userId,
+				Language:     n.defaultLanguage,
+				EmailEnabled: true,
+				InAppEnabled: true,
+			}, nil
+		}
+		return entities.NotificationPreference{}, err
+	}
+	return preference, nil
+}
+
+type SetNotificationPreferenceParams struct {
+	Language     string `json:"language" validate:"required,oneof=ru en"`
+	EmailEnabled bool   `json:"emailEnabled"`
+	InAppEnabled bool   `json:"inAppEnabled"`
+}
+
+func (n NotificationService) SetPreference(
+	ctx context.Context,
+	username string,
+	params SetNotificationPreferenceParams,
+) (entities.NotificationPreference, error) {
+	if err := validate(n.validator, params); err != nil {
+		return entities.NotificationPreference{}, err
+	}
+	userId, err := getUserId(ctx, n.s, username)
+	if err != nil {
+		return entities.NotificationPreference{}, err
+	}
+	return n.s.SetNotificationPreference(ctx, entities.NotificationPreference{
+		UserId:       userId,
+		Language:     params.Language,
+		EmailEnabled: params.EmailEnabled,
+		InAppEnabled: params.InAppEnabled,
+	})
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"backend/entities"
+	"backend/entities/author_type"
+	"backend/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"time"
+)
+
+type OrganizationService struct {
+	s         *storage.Storage
+	validator *validator.Validate
+}
+
+func NewOrganizationService(s *storage.Storage) *OrganizationService {
+	return &OrganizationService{s: s, validator: NewValidator()}
+}
+
+func (o OrganizationService) Memberships(ctx context.Context, username string) (string, []string, error) {
+	userId, err := getUserId(ctx, o.s, username)
+	if err != nil {
+		return "", nil, err
+	}
+	organizationIds, err := o.s.GetUserOrganizationIds(ctx, userId)
+	if err != nil {
+		return "", nil, err
+	}
+	return userId, organizationIds, nil
+}
+
+func (o OrganizationService) SetConflictPolicy(ctx context.Context, organizationId string, username string, policy string) (entities.Organization, error) {
+	if err := validateVar(o.validator, "policy", policy, "required,oneof=Block Flag"); err != nil {
+		return entities.Organization{}, err
+	}
+	userId, err := getUserId(ctx, o.s, username)
+	if err != nil {
+		return entities.Organization{}, err
+	}
+	if _, err := getOrganization(ctx, o.s, organizationId); err != nil {
+		return entities.Organization{}, err
+	}
+	if err := o.checkManager(ctx, userId, organizationId); err != nil {
+		return entities.Organization{}, err
+	}
+	return o.s.SetConflictPolicy(ctx, organizationId, policy)
+}
+
+type CreateBlocklistEntryParams struct {
+	SubjectType string     `json:"subjectType" validate:"required,oneof=User Organization"`
+	SubjectId   string     `json:"subjectId" validate:"required,uid"`
+	Reason      string     `json:"reason" validate:"required,max=1000"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+func (o OrganizationService) CreateBlocklistEntry(
+	ctx context.Context,
+	organizationId string,
+	username string,
+	params CreateBlocklistEntryParams,
+) (entities.BlocklistEntry, error) {
+	if err := validate(o.validator, params); err != nil {
+		return entities.BlocklistEntry{}, err
+	}
+	if params.ExpiresAt != nil && !params.ExpiresAt.After(time.Now().UTC()) {
+		return entities.BlocklistEntry{}, NewError(CodeInvalidExpiry, "Expiry date of blocklist entry must be in the future")
+	}
+	userId, err := getUserId(ctx, o.s, username)
+	if err != nil {
+		return entities.BlocklistEntry{}, err
+	}
+	if err := o.checkManager(ctx, userId, organizationId); err != nil {
+		return entities.BlocklistEntry{}, err
+	}
+	if params.SubjectType == author_type.ORGANIZATION {
+		_, err = getOrganization(ctx, o.s, params.SubjectId)
+	} else {
+		_, err = getUser(ctx, o.s, params.SubjectId)
+	}
+	if err != nil {
+		return entities.BlocklistEntry{}, err
+	}
+	return o.s.CreateBlocklistEntry(ctx, entities.BlocklistEntry{
+		OrganizationId: organizationId,
+		SubjectType:    params.SubjectType,
+		SubjectId:      params.SubjectId,
+		Reason:         params.Reason,
+		ExpiresAt:      toUTC(params.ExpiresAt),
+		CreatedBy:      &userId,
+	})
+}
+
+type GetBlocklistParams struct {
+	Limit          int    `json:"limit" validate:"min=0"`
+	Offset         int    `json:"offset" validate:"min=0"`
+	Username       string `json:"username" validate:"required,max=50"`
+	IncludeExpired bool   `json:"includeExpired"`
+}
+
+func (o OrganizationService) GetBlocklist(ctx context.Context, organizationId string, params GetBlocklistParams) ([]entities.BlocklistEntry, error) {
+	if err := validate(o.validator, params); err != nil {
+		return nil, err
+	}
+	userId, err := getUserId(ctx, o.s, params.Username)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.checkManager(ctx, userId, organizationId); err != nil {
+		return nil, err
+	}
+	return o.s.GetBlocklist(ctx, organizationId, params.IncludeExpired, params.Limit, params.Offset)
+}
+
+func (o OrganizationService) DeleteBlocklistEntry(
+	ctx context.Context,
+	organizationId string,
+	entryId string,
+	username string,
+) (entities.BlocklistEntry, error) {
+	userId, err := getUserId(ctx, o.s, username)
+	if err != nil {
+		return entities.BlocklistEntry{}, err
+	}
+	if err := o.checkManager(ctx, userId, organizationId); err != nil {
+		return entities.BlocklistEntry{}, err
+	}
+	entry, err := o.s.GetBlocklistEntry(ctx, entryId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.BlocklistEntry{}, NewError(CodeBlocklistEntryNotFound, "")
+		}
+		return entities.BlocklistEntry{}, err
+	}
+	if entry.OrganizationId != organizationId {
+		return entities.BlocklistEntry{}, NewError(CodeBlocklistEntryNotFound, "")
+	}
+	if err := o.s.DeleteBlocklistEntry(ctx, entryId); err != nil {
+		return entities.BlocklistEntry{}, err
+	}
+	return entry, nil
+}
+
+func (o OrganizationService) checkManager(ctx context.Context, userId string, organizationId string) error {
+	return checkResponsible(ctx, o.s, userId, organizationId, "User has no permission to manage this organization")
+}
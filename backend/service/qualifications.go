@@ -0,0 +1,125 @@
+package service
+
+import (
+	"backend/entities"
+	"backend/entities/qualification_status"
+	"backend/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"time"
+)
+
+type QualificationService struct {
+	s         *storage.Storage
+	validator *validator.Validate
+}
+
+func NewQualificationService(s *storage.Storage) *QualificationService {
+	return &QualificationService{s: s, validator: NewValidator()}
+}
+
+type CreateQualificationParams struct {
+	SupplierType   string `json:"supplierType" validate:"required,oneof=User Organization"`
+	SupplierId     string `json:"supplierId" validate:"required,uid"`
+	OrganizationId string `json:"organizationId" validate:"required,uid"`
+	ServiceType    string `json:"serviceType" validate:"required,oneof=Construction Delivery Manufacture"`
+	Description    string `json:"description" validate:"required,max=1000,min=1"`
+}
+
+func (q QualificationService) Create(ctx context.Context, username string, params CreateQualificationParams) (entities.Qualification, error) {
+	if err := validate(q.validator, params); err != nil {
+		return entities.Qualification{}, err
+	}
+	userId, err := getUserId(ctx, q.s, username)
+	if err != nil {
+		return entities.Qualification{}, err
+	}
+	permission, err := isAuthor(ctx, q.s, userId, params.SupplierType, params.SupplierId)
+	if err != nil {
+		return entities.Qualification{}, err
+	}
+	if !permission {
+		return entities.Qualification{}, NewError(CodePermissionDenied, "User has no permission to qualify this supplier")
+	}
+	if _, err := getOrganization(ctx, q.s, params.OrganizationId); err != nil {
+		return entities.Qualification{}, err
+	}
+	return q.s.CreateQualification(ctx, entities.Qualification{
+		SupplierType:   params.SupplierType,
+		SupplierId:     params.SupplierId,
+		OrganizationId: params.OrganizationId,
+		ServiceType:    params.ServiceType,
+		Description:    params.Description,
+	})
+}
+
+type FilterQualificationsParams struct {
+	Limit    int    `json:"limit" validate:"min=0"`
+	Offset   int    `json:"offset" validate:"min=0"`
+	Username string `json:"username" validate:"required,max=50"`
+	Status   string `json:"status" validate:"omitempty,oneof=Pending Approved Rejected"`
+}
+
+func (q QualificationService) FilterMy(ctx context.Context, params FilterQualificationsParams) ([]entities.Qualification, error) {
+	if err := validate(q.validator, params); err != nil {
+		return nil, err
+	}
+	userId, err := getUserId(ctx, q.s, params.Username)
+	if err != nil {
+		return nil, err
+	}
+	return q.s.GetMyQualifications(ctx, userId, params.Status, params.Limit, params.Offset)
+}
+
+func (q QualificationService) FilterToReview(ctx context.Context, params FilterQualificationsParams) ([]entities.Qualification, error) {
+	if err := validate(q.validator, params); err != nil {
+		return nil, err
+	}
+	if len(params.Status) == 0 {
+		params.Status = qualification_status.PENDING
+	}
+	userId, err := getUserId(ctx, q.s, params.Username)
+	if err != nil {
+		return nil, err
+	}
+	return q.s.GetQualificationsToReview(ctx, userId, params.Status, params.Limit, params.Offset)
+}
+
+type ReviewQualificationParams struct {
+	Decision  string `json:"decision" validate:"required,oneof=Approved Rejected"`
+	ExpiresAt string `json:"expiresAt" validate:"required_if=Decision Approved,omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Username  string `json:"username" validate:"required,max=50"`
+}
+
+func (q QualificationService) Review(ctx context.Context, qualificationId string, params ReviewQualificationParams) (entities.Qualification, error) {
+	if err := validate(q.validator, params); err != nil {
+		return entities.Qualification{}, err
+	}
+	var expiresAt *time.Time = nil
+	if params.Decision == qualification_status.APPROVED {
+		parsed, _ := time.Parse(time.RFC3339, params.ExpiresAt)
+		parsed = parsed.UTC()
+		if !parsed.After(time.Now().UTC()) {
+			return entities.Qualification{}, NewError(CodeInvalidExpiry, "Expiry date of qualification must be in the future")
+		}
+		expiresAt = &parsed
+	}
+	userId, err := getUserId(ctx, q.s, params.Username)
+	if err != nil {
+		return entities.Qualification{}, err
+	}
+	qualification, err := q.s.GetQualification(ctx, qualificationId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.Qualification{}, NewError(CodeQualificationNotFound, "")
+		}
+		return entities.Qualification{}, err
+	}
+	reason := "User has no permission to review this qualification"
+	if err := checkResponsible(ctx, q.s, userId, qualification.OrganizationId, reason); err != nil {
+		return entities.Qualification{}, err
+	}
+	return q.s.ReviewQualification(ctx, qualificationId, userId, params.Decision, expiresAt)
+}
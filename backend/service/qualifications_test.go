@@ -0,0 +1,60 @@
+package service
+
+import (
+	"backend/entities/author_type"
+	"backend/entities/qualification_status"
+	"context"
+	"testing"
+)
+
+func TestFilterMyQualificationsByStatus(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	ownerId := env.user(t, "owner")
+	supplierId := env.user(t, "supplier")
+	buyerId := env.organization(t, ownerId)
+	qualifications := NewQualificationService(env.s)
+	params := CreateQualificationParams{
+		SupplierType:   author_type.USER,
+		SupplierId:     supplierId,
+		OrganizationId: buyerId,
+		ServiceType:    "Delivery",
+		Description:    "Qualification",
+	}
+	if _, err := qualifications.Create(ctx, "supplier", params); err != nil {
+		t.Fatal(err)
+	}
+	params.ServiceType = "Construction"
+	rejected, err := qualifications.Create(ctx, "supplier", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	review := ReviewQualificationParams{Decision: qualification_status.REJECTED, Username: "owner"}
+	if _, err := qualifications.Review(ctx, rejected.Id, review); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		status string
+		want   int
+	}{
+		{status: "", want: 2},
+		{status: qualification_status.PENDING, want: 1},
+		{status: qualification_status.REJECTED, want: 1},
+		{status: qualification_status.APPROVED, want: 0},
+	}
+	for _, tt := range tests {
+		got, err := qualifications.FilterMy(ctx, FilterQualificationsParams{Limit: 10, Username: "supplier", Status: tt.status})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != tt.want {
+			t.Errorf("status %q: got %d qualifications, want %d", tt.status, len(got), tt.want)
+		}
+		for _, qualification := range got {
+			if len(tt.status) > 0 && qualification.Status != tt.status {
+				t.Errorf("status %q: got qualification in status %s", tt.status, qualification.Status)
+			}
+		}
+	}
+}
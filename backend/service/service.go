@@ -0,0 +1,133 @@
+package service
+
+import (
+	"backend/entities"
+	"backend/entities/author_type"
+	"backend/logging"
+	"backend/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"regexp"
+	"time"
+)
+
+func uidValidator(fl validator.FieldLevel) bool {
+	switch v := fl.Field(); v.Kind() {
+	case reflect.String:
+		match, _ := regexp.Match("[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}",
+			[]byte(v.String()))
+		return match
+	default:
+		return false
+	}
+}
+
+func NewValidator() *validator.Validate {
+	val := validator.New()
+	val.RegisterValidation("uid", uidValidator)
+	return val
+}
+
+func validate(val *validator.Validate, params any) error {
+	if err := val.Struct(params); err != nil {
+		return NewError(CodeInvalidParams, err.Error())
+	}
+	return nil
+}
+
+func validateVar(val *validator.Validate, field string, value any, tag string) error {
+	if err := val.Var(value, tag); err != nil {
+		return NewError(CodeInvalidParams, field+": "+err.Error())
+	}
+	return nil
+}
+
+// toUTC converts a user supplied time before it's stored in a TIMESTAMP column,
+// which drops the offset.
+func toUTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+func getUserId(ctx context.Context, s *storage.Storage, username string) (string, error) {
+	userId, err := s.GetUserId(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", NewError(CodeUnauthorized, "")
+		}
+		return "", err
+	}
+	logging.Set(ctx, "user_id", userId)
+	return userId, nil
+}
+
+func getUser(ctx context.Context, s *storage.Storage, userId string) (entities.Employee, error) {
+	user, err := s.GetUser(ctx, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.Employee{}, NewError(CodeUserNotFound, "")
+		}
+		return entities.Employee{}, err
+	}
+	return user, nil
+}
+
+func getTender(ctx context.Context, s *storage.Storage, tenderId string) (entities.Tender, error) {
+	tender, err := s.GetTender(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.Tender{}, NewError(CodeTenderNotFound, "")
+		}
+		return entities.Tender{}, err
+	}
+	logging.Set(ctx, "tender_id", tender.Id)
+	return tender, nil
+}
+
+func getBid(ctx context.Context, s *storage.Storage, bidId string) (entities.Bid, error) {
+	bid, err := s.GetBid(ctx, bidId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.Bid{}, NewError(CodeBidNotFound, "")
+		}
+		return entities.Bid{}, err
+	}
+	logging.Set(ctx, "bid_id", bid.Id)
+	logging.Set(ctx, "tender_id", bid.TenderId)
+	return bid, nil
+}
+
+func isAuthor(ctx context.Context, s *storage.Storage, userId string, authorType string, authorId string) (bool, error) {
+	if authorType == author_type.ORGANIZATION {
+		return s.CheckOrganizationResponsible(ctx, userId, authorId)
+	}
+	return authorId == userId, nil
+}
+
+func checkResponsible(ctx context.Context, s *storage.Storage, userId string, organizationId string, reason string) error {
+	permission, err := s.CheckOrganizationResponsible(ctx, userId, organizationId)
+	if err != nil {
+		return err
+	}
+	if !permission {
+		return NewError(CodePermissionDenied, reason)
+	}
+	return nil
+}
+
+func getOrganization(ctx context.Context, s *storage.Storage, organizationId string) (entities.Organization, error) {
+	organization, err := s.GetOrganization(ctx, organizationId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.Organization{}, NewError(CodeOrganizationNotFound, "")
+		}
+		return entities.Organization{}, err
+	}
+	return organization, nil
+}
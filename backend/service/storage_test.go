@@ -0,0 +1,237 @@
+package service
+
+import (
+	"backend/config"
+	"backend/conflict"
+	"backend/entities"
+	"backend/entities/author_type"
+	"backend/entities/bid_status"
+	"backend/entities/tender_status"
+	"backend/events"
+	"backend/metrics"
+	"backend/notifications"
+	"backend/storage"
+	"backend/tracing"
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+	"go.uber.org/fx/fxtest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testEnv is a schema of its own in the database from TEST_POSTGRES_DSN with
+// every migration except the sample data applied.
+type testEnv struct {
+	db  *sql.DB
+	s   *storage.Storage
+	cfg *config.Config
+}
+
+type nopSender struct{}
+
+func (nopSender) Send(string, string, string) error {
+	return nil
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if len(dsn) == 0 {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+	t.Setenv("POSTGRES_DSN", dsn)
+	cfg, err := config.Load(config.DefaultPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin := stdlib.OpenDB(*connConfig)
+	t.Cleanup(func() { admin.Close() })
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec("DROP SCHEMA " + schema + " CASCADE"); err != nil {
+			t.Error(err)
+		}
+	})
+	connConfig.RuntimeParams["search_path"] = schema
+	db := stdlib.OpenDB(*connConfig)
+	t.Cleanup(func() { db.Close() })
+	migrate(t, db)
+
+	lc := fxtest.NewLifecycle(t)
+	tr, err := tracing.NewTracing(lc, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := storage.NewStorage(db, cfg, events.NewHub(cfg), metrics.NewMetrics(db), tr)
+	return &testEnv{db: db, s: s, cfg: cfg}
+}
+
+// migrate runs the Up part of every migration, one goose statement at a time.
+func migrate(t *testing.T, db *sql.DB) {
+	t.Helper()
+	files, err := filepath.Glob("../migrations/*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		if strings.Contains(file, "fill_tables") {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, up, _ := strings.Cut(string(content), "-- +goose Up")
+		up, _, _ = strings.Cut(up, "-- +goose Down")
+		for _, statement := range strings.Split(up, "-- +goose StatementEnd") {
+			if !hasSQL(statement) {
+				continue
+			}
+			if _, err := db.Exec(statement); err != nil {
+				t.Fatalf("%s: %v", filepath.Base(file), err)
+			}
+		}
+	}
+}
+
+func hasSQL(statement string) bool {
+	for _, line := range strings.Split(statement, "\n") {
+		if line = strings.TrimSpace(line); len(line) > 0 && !strings.HasPrefix(line, "--") {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *testEnv) user(t *testing.T, username string) string {
+	t.Helper()
+	var id string
+	query := "INSERT INTO employee (username, first_name, last_name) VALUES ($1, $1, $1) RETURNING id"
+	if err := e.db.QueryRow(query, username).Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func (e *testEnv) organization(t *testing.T, responsibleIds ...string) string {
+	t.Helper()
+	var id string
+	query := "INSERT INTO organization (name, description, type) VALUES ('Org', 'Org', 'LLC') RETURNING id"
+	if err := e.db.QueryRow(query).Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+	for _, userId := range responsibleIds {
+		query := "INSERT INTO organization_responsible (organization_id, user_id) VALUES ($1, $2)"
+		if _, err := e.db.Exec(query, id, userId); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return id
+}
+
+func (e *testEnv) tenders() *TenderService {
+	return NewTenderService(e.s, metrics.NewMetrics(e.db))
+}
+
+func (e *testEnv) bids(t *testing.T) *BidService {
+	n := notifications.NewService(e.s, nopSender{}, e.cfg)
+	t.Cleanup(func() { _ = n.Wait(context.Background()) })
+	return NewBidService(e.s, conflict.NewChecker(e.s), n, metrics.NewMetrics(e.db))
+}
+
+func tenderParams(organizationId string) CreateTenderParams {
+	return CreateTenderParams{
+		Name:           "Tender",
+		Description:    "Tender",
+		ServiceType:    []string{"Delivery"},
+		OrganizationId: organizationId,
+	}
+}
+
+// publishedTender creates a tender on behalf of username and publishes it.
+func (e *testEnv) publishedTender(t *testing.T, username string, params CreateTenderParams) entities.Tender {
+	t.Helper()
+	ctx := context.Background()
+	tender, err := e.tenders().Create(ctx, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tender, err = e.tenders().UpdateStatus(ctx, tender.Id, username, tender_status.PUBLISHED)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tender
+}
+
+// publishedBid submits a bid of the user username on the tender and publishes it.
+func publishedBid(t *testing.T, bids *BidService, username string, userId string, tenderId string, amount *float64) entities.Bid {
+	t.Helper()
+	ctx := context.Background()
+	bid, err := bids.Create(ctx, username, CreateBidParams{
+		Name:        "Bid",
+		Description: "Bid",
+		TenderId:    tenderId,
+		AuthorType:  author_type.USER,
+		AuthorId:    userId,
+		Amount:      amount,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bid, err = bids.ChangeStatus(ctx, bid.Id, username, bid_status.PUBLISHED)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bid
+}
+
+func wantCode(t *testing.T, err error, code *Code) {
+	t.Helper()
+	serviceErr, ok := AsError(err)
+	if !ok || serviceErr.Code != code {
+		t.Fatalf("error = %v, want %s", err, code.Name)
+	}
+}
+
+func TestIdempotencyKeyLease(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	reserve := func(lockTimeout time.Duration) bool {
+		t.Helper()
+		_, reserved, err := env.s.ReserveIdempotencyKey(ctx, "key", "fingerprint", time.Hour, lockTimeout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reserved
+	}
+	if !reserve(time.Hour) {
+		t.Fatal("new key is not reserved")
+	}
+	if reserve(time.Hour) {
+		t.Error("key is taken over while its request may still run")
+	}
+	if !reserve(0) {
+		t.Error("key without a response is not taken over after the lock timeout")
+	}
+	if err := env.s.SaveIdempotentResponse(ctx, "key", 201, "application/json", []byte("{}")); err != nil {
+		t.Fatal(err)
+	}
+	if reserve(0) {
+		t.Error("key with a stored response is taken over")
+	}
+}
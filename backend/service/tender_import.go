@@ -0,0 +1,142 @@
+package service
+
+import (
+	"backend/entities/language"
+	"backend/storage"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"strings"
+)
+
+const MaxImportRows = 1000
+
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportRow is one spreadsheet row. Errors holds problems found while parsing
+// cells, the row is not validated further if there are any.
+type ImportRow struct {
+	Row    int
+	Params CreateTenderParams
+	Errors []ImportRowError
+}
+
+type ImportResult struct {
+	DryRun    bool             `json:"dryRun"`
+	Total     int              `json:"total"`
+	Valid     bool             `json:"valid"`
+	TenderIds []string         `json:"tenderIds"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+// Import validates every row with the rules of Create and, unless dryRun is
+// set or some row is invalid, creates all tenders in one transaction.
+func (t TenderService) Import(ctx context.Context, rows []ImportRow, dryRun bool) (ImportResult, error) {
+	if len(rows) == 0 {
+		return ImportResult{}, NewError(CodeInvalidBody, "File has no rows")
+	}
+	if len(rows) > MaxImportRows {
+		return ImportResult{}, NewError(CodeInvalidBody, fmt.Sprintf("File has more than %d rows", MaxImportRows))
+	}
+	result := ImportResult{DryRun: dryRun, Total: len(rows), TenderIds: make([]string, 0), Errors: make([]ImportRowError, 0)}
+	organizations := make(map[string]error)
+	for _, row := range rows {
+		rowErrors, err := t.checkImportRow(ctx, row, organizations)
+		if err != nil {
+			return ImportResult{}, err
+		}
+		result.Errors = append(result.Errors, rowErrors...)
+	}
+	result.Valid = len(result.Errors) == 0
+	if !result.Valid || dryRun {
+		return result, nil
+	}
+	err := t.s.WithTx(ctx, func(tx *storage.Storage) error {
+		result.TenderIds = result.TenderIds[:0]
+		for _, row := range rows {
+			tender, err := tx.CreateTender(ctx, newTender(row.Params))
+			if err != nil {
+				return err
+			}
+			result.TenderIds = append(result.TenderIds, tender.Id)
+		}
+		return nil
+	})
+	if err != nil {
+		return ImportResult{}, err
+	}
+	for range result.TenderIds {
+		t.metrics.TenderCreated()
+	}
+	return result, nil
+}
+
+func (t TenderService) checkImportRow(
+	ctx context.Context,
+	row ImportRow,
+	organizations map[string]error,
+) ([]ImportRowError, error) {
+	if len(row.Errors) > 0 {
+		return row.Errors, nil
+	}
+	var rowErrors []ImportRowError
+	var fieldErrors validator.ValidationErrors
+	if err := t.validator.Struct(row.Params); errors.As(err, &fieldErrors) {
+		for _, fieldError := range fieldErrors {
+			rowErrors = append(rowErrors, ImportRowError{
+				Row:     row.Row,
+				Field:   jsonName(reflect.TypeOf(row.Params), fieldError.StructField()),
+				Message: "failed " + strings.TrimSpace(fieldError.Tag()+" "+fieldError.Param()) + " check",
+			})
+		}
+		return rowErrors, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if !auctionPeriodValid(row.Params) {
+		rowErrors = append(rowErrors, ImportRowError{
+			Row:     row.Row,
+			Field:   "auctionEndsAt",
+			Message: "Auction must end after it starts",
+		})
+	}
+	organizationId := row.Params.OrganizationId
+	err, checked := organizations[organizationId]
+	if !checked {
+		_, err = t.s.GetOrganization(ctx, organizationId)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		organizations[organizationId] = err
+	}
+	if err != nil {
+		rowErrors = append(rowErrors, ImportRowError{
+			Row:     row.Row,
+			Field:   "organizationId",
+			Message: CodeOrganizationNotFound.Message(language.EN),
+		})
+	}
+	return rowErrors, nil
+}
+
+// jsonName maps a struct field, possibly with an index like ServiceType[1],
+// to its JSON name.
+func jsonName(t reflect.Type, field string) string {
+	field, index, _ := strings.Cut(field, "[")
+	structField, ok := t.FieldByName(field)
+	if !ok {
+		return field
+	}
+	name, _, _ := strings.Cut(structField.Tag.Get("json"), ",")
+	if len(index) > 0 {
+		name += "[" + index
+	}
+	return name
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"backend/auction"
+	"backend/entities"
+	"backend/entities/auction_status"
+	"backend/entities/tender_status"
+	"backend/metrics"
+	"backend/storage"
+	"context"
+	"github.com/go-playground/validator/v10"
+	"time"
+)
+
+type TenderService struct {
+	s         *storage.Storage
+	metrics   *metrics.Metrics
+	validator *validator.Validate
+}
+
+func NewTenderService(s *storage.Storage, m *metrics.Metrics) *TenderService {
+	return &TenderService{s: s, metrics: m, validator: NewValidator()}
+}
+
+type CreateTenderParams struct {
+	Name                 string     `json:"name" validate:"required,max=50,min=5"`
+	Description          string     `json:"description" validate:"required,max=1000,min=1"`
+	ServiceType          []string   `json:"serviceType" validate:"max=3,dive,oneof=Construction Delivery Manufacture"`
+	OrganizationId       string     `json:"organizationId" validate:"required,uid"`
+	Deadline             *time.Time `json:"deadline,omitempty"`
+	RequireQualification bool       `json:"requireQualification"`
+	Budget               *float64   `json:"budget,omitempty" validate:"omitempty,gt=0"`
+	Currency             *string    `json:"currency,omitempty" validate:"required_with=Budget,omitempty,iso4217"`
+	BudgetPublic         bool       `json:"budgetPublic"`
+	RejectOverBudget     bool       `json:"rejectOverBudget"`
+	AuctionStartsAt      *time.Time `json:"auctionStartsAt,omitempty" validate:"required_with=AuctionEndsAt"`
+	AuctionEndsAt        *time.Time `json:"auctionEndsAt,omitempty" validate:"required_with=AuctionStartsAt"`
+	AuctionExtension     int        `json:"auctionExtensionSeconds" validate:"min=0,max=3600"`
+}
+
+func (t TenderService) Create(ctx context.Context, params CreateTenderParams) (entities.Tender, error) {
+	if err := validate(t.validator, params); err != nil {
+		return entities.Tender{}, err
+	}
+	if !auctionPeriodValid(params) {
+		return entities.Tender{}, NewError(CodeInvalidAuction, "Auction must end after it starts")
+	}
+	tender, err := t.s.CreateTender(ctx, newTender(params))
+	if err != nil {
+		return entities.Tender{}, err
+	}
+	t.metrics.TenderCreated()
+	return tender, nil
+}
+
+func auctionPeriodValid(params CreateTenderParams) bool {
+	return params.AuctionStartsAt == nil || params.AuctionEndsAt.After(*params.AuctionStartsAt)
+}
+
+func newTender(params CreateTenderParams) entities.Tender {
+	return entities.Tender{
+		Name:                 params.Name,
+		Description:          params.Description,
+		ServiceType:          params.ServiceType,
+		OrganizationId:       params.OrganizationId,
+		Deadline:             toUTC(params.Deadline),
+		RequireQualification: params.RequireQualification,
+		Budget:               params.Budget,
+		Currency:             params.Currency,
+		BudgetPublic:         params.BudgetPublic,
+		RejectOverBudget:     params.RejectOverBudget,
+		AuctionStartsAt:      toUTC(params.AuctionStartsAt),
+		AuctionEndsAt:        toUTC(params.AuctionEndsAt),
+		AuctionExtension:     params.AuctionExtension,
+	}
+}
+
+type FilterTendersParams struct {
+	Limit       int      `json:"limit" validate:"min=0"`
+	Offset      int      `json:"offset" validate:"min=0"`
+	ServiceType []string `json:"serviceType" validate:"max=3,dive,oneof=Construction Delivery Manufacture"`
+}
+
+func (t TenderService) Filter(ctx context.Context, params FilterTendersParams) ([]entities.Tender, error) {
+	if err := validate(t.validator, params); err != nil {
+		return nil, err
+	}
+	tenders, err := t.s.FilterTenders(ctx, params.Limit, params.Offset, params.ServiceType)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tenders {
+		if !tenders[i].BudgetPublic {
+			tenders[i].Budget = nil
+			tenders[i].Currency = nil
+		}
+	}
+	return tenders, nil
+}
+
+type FilterMyTendersParams struct {
+	Limit    int    `json:"limit" validate:"min=0"`
+	Offset   int    `json:"offset" validate:"min=0"`
+	Username string `json:"username" validate:"required,max=50"`
+}
+
+func (t TenderService) FilterMy(ctx context.Context, params FilterMyTendersParams) ([]entities.Tender, error) {
+	if err := validate(t.validator, params); err != nil {
+		return nil, err
+	}
+	userId, err := getUserId(ctx, t.s, params.Username)
+	if err != nil {
+		return nil, err
+	}
+	return t.s.FilterUsersTenders(ctx, params.Limit, params.Offset, userId)
+}
+
+func (t TenderService) GetStatus(ctx context.Context, tenderId string, username string) (entities.Status, error) {
+	tender, err := getTender(ctx, t.s, tenderId)
+	if err != nil {
+		return entities.Status{}, err
+	}
+	if tender.Status != tender_status.PUBLISHED {
+		userId, err := getUserId(ctx, t.s, username)
+		if err != nil {
+			return entities.Status{}, err
+		}
+		if err := checkOwner(ctx, t.s, userId, tender); err != nil {
+			return entities.Status{}, err
+		}
+	}
+	return entities.Status{Status: tender.Status, Version: tender.Version, UpdatedAt: tender.UpdatedAt}, nil
+}
+
+func (t TenderService) UpdateStatus(ctx context.Context, tenderId string, username string, status string) (entities.Tender, error) {
+	if err := validateVar(t.validator, "status", status, "required,oneof=Created Published Closed"); err != nil {
+		return entities.Tender{}, err
+	}
+	userId, err := getUserId(ctx, t.s, username)
+	if err != nil {
+		return entities.Tender{}, err
+	}
+	var tenderNew entities.Tender
+	var published bool
+	err = t.s.WithTx(ctx, func(tx *storage.Storage) error {
+		tender, err := getTender(ctx, tx, tenderId)
+		if err != nil {
+			return err
+		}
+		if err := checkOwner(ctx, tx, userId, tender); err != nil {
+			return err
+		}
+		tenderNew, err = tx.PatchTender(ctx, tenderId, storage.TenderPatch{Status: &status})
+		published = isPublished(tender, tenderNew)
+		return err
+	})
+	if err != nil {
+		return entities.Tender{}, err
+	}
+	if published {
+		t.metrics.TenderPublished()
+	}
+	return tenderNew, nil
+}
+
+type EditTenderParams struct {
+	Name                 string     `json:"name,omitempty" validate:"omitempty,max=50"`
+	Description          string     `json:"description,omitempty" validate:"omitempty,max=1000,min=1"`
+	ServiceType          []string   `json:"serviceType,omitempty" validate:"omitempty,max=3,dive,oneof=Construction Delivery Manufacture"`
+	Status               string     `json:"status,omitempty" validate:"omitempty,oneof=Created Published Closed"`
+	Deadline             *time.Time `json:"deadline,omitempty"`
+	RequireQualification *bool      `json:"requireQualification,omitempty"`
+	Budget               *float64   `json:"budget,omitempty" validate:"omitempty,gt=0"`
+	Currency             *string    `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	BudgetPublic         *bool      `json:"budgetPublic,omitempty"`
+	RejectOverBudget     *bool      `json:"rejectOverBudget,omitempty"`
+	AuctionStartsAt      *time.Time `json:"auctionStartsAt,omitempty"`
+	AuctionEndsAt        *time.Time `json:"auctionEndsAt,omitempty"`
+	AuctionExtension     *int       `json:"auctionExtensionSeconds,omitempty" validate:"omitempty,min=0,max=3600"`
+}
+
+func (t TenderService) Edit(ctx context.Context, tenderId string, username string, params EditTenderParams) (entities.Tender, error) {
+	if err := validate(t.validator, params); err != nil {
+		return entities.Tender{}, err
+	}
+	userId, err := getUserId(ctx, t.s, username)
+	if err != nil {
+		return entities.Tender{}, err
+	}
+	var tenderNew entities.Tender
+	var published bool
+	err = t.s.WithTx(ctx, func(tx *storage.Storage) error {
+		tender, err := getTender(ctx, tx, tenderId)
+		if err != nil {
+			return err
+		}
+		if err := checkOwner(ctx, tx, userId, tender); err != nil {
+			return err
+		}
+
+		patch := storage.TenderPatch{
+			ServiceType:          params.ServiceType,
+			Deadline:             toUTC(params.Deadline),
+			RequireQualification: params.RequireQualification,
+			Budget:               params.Budget,
+			Currency:             params.Currency,
+			BudgetPublic:         params.BudgetPublic,
+			RejectOverBudget:     params.RejectOverBudget,
+			AuctionStartsAt:      toUTC(params.AuctionStartsAt),
+			AuctionEndsAt:        toUTC(params.AuctionEndsAt),
+			AuctionExtension:     params.AuctionExtension,
+		}
+		auctionStartsAt, auctionEndsAt := tender.AuctionStartsAt, tender.AuctionEndsAt
+		if params.AuctionStartsAt != nil {
+			auctionStartsAt = params.AuctionStartsAt
+		}
+		if params.AuctionEndsAt != nil {
+			auctionEndsAt = params.AuctionEndsAt
+		}
+		if (auctionStartsAt == nil) != (auctionEndsAt == nil) {
+			return NewError(CodeInvalidAuction, "Auction needs both start and end")
+		}
+		if auctionStartsAt != nil && !auctionEndsAt.After(*auctionStartsAt) {
+			return NewError(CodeInvalidAuction, "Auction must end after it starts")
+		}
+		if len(params.Name) > 0 {
+			patch.Name = &params.Name
+		}
+		if len(params.Description) > 0 {
+			patch.Description = &params.Description
+		}
+		if len(params.Status) > 0 {
+			patch.Status = &params.Status
+		}
+		tenderNew, err = tx.PatchTender(ctx, tenderId, patch)
+		published = isPublished(tender, tenderNew)
+		return err
+	})
+	if err != nil {
+		return entities.Tender{}, err
+	}
+	if published {
+		t.metrics.TenderPublished()
+	}
+	return tenderNew, nil
+}
+
+func (t TenderService) GetAuction(ctx context.Context, tenderId string, username string) (entities.Auction, error) {
+	tender, err := getTender(ctx, t.s, tenderId)
+	if err != nil {
+		return entities.Auction{}, err
+	}
+	status := auction.Status(tender, time.Now().UTC())
+	if len(status) == 0 {
+		return entities.Auction{}, NewError(CodeAuctionNotFound, "")
+	}
+	if tender.Status == tender_status.CREATED {
+		userId, err := getUserId(ctx, t.s, username)
+		if err != nil {
+			return entities.Auction{}, err
+		}
+		if err := checkOwner(ctx, t.s, userId, tender); err != nil {
+			return entities.Auction{}, err
+		}
+	}
+	offers, err := t.s.GetAuctionOffers(ctx, tenderId)
+	if err != nil {
+		return entities.Auction{}, err
+	}
+	if status == auction_status.FINISHED {
+		// The worker stores the final ranking shortly after the end, until then
+		// the live offers are shown.
+		ranking, err := t.s.GetAuctionRanking(ctx, tenderId)
+		if err != nil {
+			return entities.Auction{}, err
+		}
+		if len(ranking) > 0 {
+			offers = ranking
+		}
+	}
+	result := entities.Auction{
+		TenderId:    tenderId,
+		Status:      status,
+		StartsAt:    *tender.AuctionStartsAt,
+		EndsAt:      *tender.AuctionEndsAt,
+		Currency:    tender.Currency,
+		OffersCount: len(offers),
+	}
+	if len(offers) > 0 {
+		result.BestPrice = &offers[0].Amount
+	}
+	if status == auction_status.FINISHED {
+		result.Ranking = offers
+	}
+	return result, nil
+}
+
+func isPublished(before entities.Tender, after entities.Tender) bool {
+	return before.Status != tender_status.PUBLISHED && after.Status == tender_status.PUBLISHED
+}
+
+func checkOwner(ctx context.Context, s *storage.Storage, userId string, tender entities.Tender) error {
+	return checkResponsible(ctx, s, userId, tender.OrganizationId, "user has no permission to see this tender")
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"backend/entities"
+	"context"
+	"time"
+)
+
+func (s Storage) GetAuctionOffers(ctx context.Context, tenderId string) ([]entities.AuctionOffer, error) {
+	defer s.observe("GetAuctionOffers", time.Now())
+	query := "SELECT id, amount FROM bid WHERE tender_id=$1 AND status='Published' AND amount IS NOT NULL " +
+		"ORDER BY amount, updated_at"
+	rows, err := s.q.QueryContext(ctx, query, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	offers := make([]entities.AuctionOffer, 0)
+	for rows.Next() {
+		offer := entities.AuctionOffer{Rank: len(offers) + 1}
+		if err := rows.Scan(&offer.BidId, &offer.Amount); err != nil {
+			return nil, err
+		}
+		offers = append(offers, offer)
+	}
+	return offers, rows.Err()
+}
+
+// GetAuctionRanking returns the ranking stored by FinishAuction, it is empty
+// until the auction is finished.
+func (s Storage) GetAuctionRanking(ctx context.Context, tenderId string) ([]entities.AuctionOffer, error) {
+	defer s.observe("GetAuctionRanking", time.Now())
+	query := "SELECT auction_rank, id, amount FROM bid WHERE tender_id=$1 AND auction_rank IS NOT NULL ORDER BY auction_rank"
+	rows, err := s.q.QueryContext(ctx, query, tenderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	offers := make([]entities.AuctionOffer, 0)
+	for rows.Next() {
+		var offer entities.AuctionOffer
+		if err := rows.Scan(&offer.Rank, &offer.BidId, &offer.Amount); err != nil {
+			return nil, err
+		}
+		offers = append(offers, offer)
+	}
+	return offers, rows.Err()
+}
+
+// GetBestOffer returns the lowest published offer of the author on the
+// tender, leaving out the bid with excludeBidId if it is set.
+func (s Storage) GetBestOffer(ctx context.Context, tenderId string, authorType string, authorId string, excludeBidId string) (*float64, error) {
+	defer s.observe("GetBestOffer", time.Now())
+	query := "SELECT MIN(amount) FROM bid WHERE tender_id=$1 AND author_type=$2 AND author_id=$3 AND status='Published'"
+	args := []any{tenderId, authorType, authorId}
+	if len(excludeBidId) > 0 {
+		query += " AND id<>$4"
+		args = append(args, excludeBidId)
+	}
+	var best *float64
+	if err := s.q.QueryRowContext(ctx, query, args...).Scan(&best); err != nil {
+		return nil, err
+	}
+	return best, nil
+}
+
+func (s Storage) ExtendAuction(ctx context.Context, tenderId string, endsAt time.Time) error {
+	defer s.observe("ExtendAuction", time.Now())
+	query := "UPDATE tender SET auction_ends_at=$2 WHERE id=$1 AND auction_ends_at<$2"
+	_, err := s.q.ExecContext(ctx, query, tenderId, endsAt)
+	if err != nil {
+		return err
+	}
+	s.publishTenderById(ctx, tenderId)
+	return nil
+}
+
+func (s Storage) GetFinishedAuctions(ctx context.Context, now time.Time) ([]string, error) {
+	defer s.observe("GetFinishedAuctions", time.Now())
+	query := "SELECT id FROM tender WHERE status='Published' AND auction_ends_at IS NOT NULL AND auction_ends_at<=$1"
+	rows, err := s.q.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (s Storage) FinishAuction(ctx context.Context, tenderId string) error {
+	defer s.observe("FinishAuction", time.Now())
+	return s.WithTx(ctx, func(tx *Storage) error {
+		rankQuery := "UPDATE bid SET auction_rank=ranked.rank FROM (" +
+			"SELECT id, ROW_NUMBER() OVER (ORDER BY amount, updated_at) AS rank FROM bid " +
+			"WHERE tender_id=$1 AND status='Published' AND amount IS NOT NULL" +
+			") AS ranked WHERE bid.id=ranked.id"
+		if _, err := tx.q.ExecContext(ctx, rankQuery, tenderId); err != nil {
+			return err
+		}
+		closeQuery := "UPDATE tender SET status='Closed', version=version+1, updated_at=$2 WHERE id=$1 AND status='Published'"
+		if _, err := tx.q.ExecContext(ctx, closeQuery, tenderId, time.Now().UTC()); err != nil {
+			return err
+		}
+		tx.publishTenderById(ctx, tenderId)
+		return nil
+	})
+}
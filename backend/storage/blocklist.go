@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"backend/entities"
+	"backend/entities/author_type"
+	"context"
+	"github.com/lib/pq"
+	"time"
+)
+
+const blocklistEntryColumns = "id, organization_id, subject_type, subject_id, reason, expires_at, created_by, created_at"
+
+func scanBlocklistEntry(row rowScanner) (entities.BlocklistEntry, error) {
+	var entry entities.BlocklistEntry
+	err := row.Scan(
+		&entry.Id,
+		&entry.OrganizationId,
+		&entry.SubjectType,
+		&entry.SubjectId,
+		&entry.Reason,
+		&entry.ExpiresAt,
+		&entry.CreatedBy,
+		&entry.CreatedAt,
+	)
+	return entry, err
+}
+
+func (s Storage) CreateBlocklistEntry(ctx context.Context, entry entities.BlocklistEntry) (entities.BlocklistEntry, error) {
+	defer s.observe("CreateBlocklistEntry", time.Now())
+	query := "INSERT INTO blocklist_entry " +
+		"(organization_id, subject_type, subject_id, reason, expires_at, created_by, created_at) " +
+		"VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id"
+	entry.CreatedAt = time.Now().UTC()
+	err := s.q.QueryRowContext(ctx,
+		query,
+		entry.OrganizationId,
+		entry.SubjectType,
+		entry.SubjectId,
+		entry.Reason,
+		entry.ExpiresAt,
+		entry.CreatedBy,
+		entry.CreatedAt,
+	).Scan(&entry.Id)
+	if err != nil {
+		return entities.BlocklistEntry{}, err
+	}
+	return entry, nil
+}
+
+func (s Storage) GetBlocklistEntry(ctx context.Context, id string) (entities.BlocklistEntry, error) {
+	defer s.observe("GetBlocklistEntry", time.Now())
+	query := "SELECT " + blocklistEntryColumns + " FROM blocklist_entry WHERE id=$1"
+	return scanBlocklistEntry(s.q.QueryRowContext(ctx, query, id))
+}
+
+func (s Storage) GetBlocklist(
+	ctx context.Context,
+	organizationId string,
+	includeExpired bool,
+	limit int,
+	offset int,
+) ([]entities.BlocklistEntry, error) {
+	defer s.observe("GetBlocklist", time.Now())
+	query := "SELECT " + blocklistEntryColumns + " FROM blocklist_entry WHERE organization_id=$1"
+	args := []any{organizationId, limit, offset}
+	if !includeExpired {
+		query += " AND (expires_at IS NULL OR expires_at>$4)"
+		args = append(args, time.Now().UTC())
+	}
+	query += " ORDER BY created_at DESC LIMIT $2 OFFSET $3"
+	rows, err := s.q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	entries := make([]entities.BlocklistEntry, 0)
+	for rows.Next() {
+		entry, err := scanBlocklistEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+func (s Storage) DeleteBlocklistEntry(ctx context.Context, id string) error {
+	defer s.observe("DeleteBlocklistEntry", time.Now())
+	query := "DELETE FROM blocklist_entry WHERE id=$1"
+	_, err := s.q.ExecContext(ctx, query, id)
+	return err
+}
+
+// GetActiveBlock returns the latest active entry of the organization's blocklist
+// that matches any of the given users or organizations.
+func (s Storage) GetActiveBlock(
+	ctx context.Context,
+	organizationId string,
+	userIds []string,
+	organizationIds []string,
+) (entities.BlocklistEntry, error) {
+	defer s.observe("GetActiveBlock", time.Now())
+	query := "SELECT " + blocklistEntryColumns + " FROM blocklist_entry " +
+		"WHERE organization_id=$1 AND (expires_at IS NULL OR expires_at>$2) AND (" +
+		"(subject_type=$3 AND subject_id=ANY($4)) OR (subject_type=$5 AND subject_id=ANY($6))) " +
+		"ORDER BY created_at DESC LIMIT 1"
+	row := s.q.QueryRowContext(ctx,
+		query,
+		organizationId,
+		time.Now().UTC(),
+		author_type.USER,
+		pq.Array(userIds),
+		author_type.ORGANIZATION,
+		pq.Array(organizationIds),
+	)
+	return scanBlocklistEntry(row)
+}
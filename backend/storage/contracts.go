@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"backend/entities"
+	"backend/entities/contract_status"
+	"context"
+	"time"
+)
+
+const contractColumns = "id, tender_id, tender_version, bid_id, bid_version, buyer_organization_id, supplier_type, " +
+	"supplier_id, amount, status, version, created_at, updated_at"
+
+func scanContract(row rowScanner) (entities.Contract, error) {
+	var contract entities.Contract
+	err := row.Scan(
+		&contract.Id,
+		&contract.TenderId,
+		&contract.TenderVersion,
+		&contract.BidId,
+		&contract.BidVersion,
+		&contract.BuyerOrganizationId,
+		&contract.SupplierType,
+		&contract.SupplierId,
+		&contract.Amount,
+		&contract.Status,
+		&contract.Version,
+		&contract.CreatedAt,
+		&contract.UpdatedAt,
+	)
+	return contract, err
+}
+
+func (s Storage) CreateContract(ctx context.Context, tender entities.Tender, bid entities.Bid) (entities.Contract, error) {
+	defer s.observe("CreateContract", time.Now())
+	query := "INSERT INTO contract " +
+		"(tender_id, tender_version, bid_id, bid_version, buyer_organization_id, supplier_type, supplier_id, amount, " +
+		"status, version, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'Draft', 1, $9, $9) RETURNING id"
+	creationTime := time.Now().UTC()
+	contract := entities.Contract{
+		TenderId:            tender.Id,
+		TenderVersion:       tender.Version,
+		BidId:               bid.Id,
+		BidVersion:          bid.Version,
+		BuyerOrganizationId: tender.OrganizationId,
+		SupplierType:        bid.AuthorType,
+		SupplierId:          bid.AuthorId,
+		Amount:              bid.Amount,
+		Status:              contract_status.DRAFT,
+		Version:             1,
+		CreatedAt:           creationTime,
+		UpdatedAt:           creationTime,
+	}
+	err := s.q.QueryRowContext(ctx,
+		query,
+		contract.TenderId,
+		contract.TenderVersion,
+		contract.BidId,
+		contract.BidVersion,
+		contract.BuyerOrganizationId,
+		contract.SupplierType,
+		contract.SupplierId,
+		contract.Amount,
+		creationTime,
+	).Scan(&contract.Id)
+	if err != nil {
+		return entities.Contract{}, err
+	}
+	return contract, nil
+}
+
+func (s Storage) HasContract(ctx context.Context, tenderId string) (bool, error) {
+	defer s.observe("HasContract", time.Now())
+	var exists bool
+	err := s.q.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM contract WHERE tender_id=$1)", tenderId).Scan(&exists)
+	return exists, err
+}
+
+func (s Storage) GetContract(ctx context.Context, id string) (entities.Contract, error) {
+	defer s.observe("GetContract", time.Now())
+	query := "SELECT " + contractColumns + " FROM contract WHERE id=$1"
+	return scanContract(s.q.QueryRowContext(ctx, query, id))
+}
+
+func (s Storage) GetMyContracts(ctx context.Context, userId string, limit int, offset int) ([]entities.Contract, error) {
+	defer s.observe("GetMyContracts", time.Now())
+	query := "SELECT " + contractColumns + " FROM contract " +
+		"WHERE buyer_organization_id IN (SELECT organization_id FROM organization_responsible WHERE user_id=$1) " +
+		"OR (supplier_type='User' AND supplier_id=$1) OR (supplier_type='Organization' AND supplier_id IN " +
+		"(SELECT organization_id FROM organization_responsible WHERE user_id=$1)) " +
+		"ORDER BY created_at DESC LIMIT $2 OFFSET $3"
+	rows, err := s.q.QueryContext(ctx, query, userId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	contracts := make([]entities.Contract, 0)
+	for rows.Next() {
+		contract, err := scanContract(rows)
+		if err != nil {
+			return nil, err
+		}
+		contracts = append(contracts, contract)
+	}
+	return contracts, rows.Err()
+}
+
+func (s Storage) SetContractStatus(ctx context.Context, id string, status string) (entities.Contract, error) {
+	defer s.observe("SetContractStatus", time.Now())
+	return inTx(ctx, s, func(tx *Storage) (entities.Contract, error) {
+		query := "UPDATE contract SET status=$2, version=version+1, updated_at=$3 WHERE id=$1"
+		_, err := tx.q.ExecContext(ctx, query, id, status, time.Now().UTC())
+		if err != nil {
+			return entities.Contract{}, err
+		}
+		return tx.GetContract(ctx, id)
+	})
+}
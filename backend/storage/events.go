@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"backend/entities"
+	"backend/events"
+	"context"
+	"log/slog"
+)
+
+func (s Storage) publish(event events.Event) {
+	if s.pending != nil {
+		*s.pending = append(*s.pending, event)
+		return
+	}
+	s.hub.Publish(event)
+}
+
+func (s Storage) publishTender(eventType string, tender entities.Tender) {
+	s.publish(events.TenderEvent(eventType, tender))
+}
+
+func (s Storage) publishTenderById(ctx context.Context, tenderId string) {
+	tender, err := s.GetTender(ctx, tenderId)
+	if err != nil {
+		slog.ErrorContext(ctx, "can't load tender", "component", "events", "tender_id", tenderId, "error", err)
+		return
+	}
+	s.publishTender(events.TENDER_UPDATED, tender)
+}
+
+func (s Storage) publishBid(ctx context.Context, eventType string, bid entities.Bid) {
+	tender, err := s.GetTender(ctx, bid.TenderId)
+	if err != nil {
+		slog.ErrorContext(ctx, "can't load tender", "component", "events", "tender_id", bid.TenderId, "error", err)
+		return
+	}
+	s.publish(events.BidEvent(eventType, tender, bid))
+}
+
+func (s Storage) publishDecision(ctx context.Context, bidId string, decision string) {
+	bid, err := s.GetBid(ctx, bidId)
+	if err != nil {
+		slog.ErrorContext(ctx, "can't load bid", "component", "events", "bid_id", bidId, "error", err)
+		return
+	}
+	tender, err := s.GetTender(ctx, bid.TenderId)
+	if err != nil {
+		slog.ErrorContext(ctx, "can't load tender", "component", "events", "tender_id", bid.TenderId, "error", err)
+		return
+	}
+	event := events.BidEvent(events.DECISION_CREATED, tender, bid)
+	event.Decision = decision
+	s.publish(event)
+}
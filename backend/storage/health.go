@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+func (s Storage) Ping(ctx context.Context) error {
+	defer s.observe("Ping", time.Now())
+	return s.db.PingContext(ctx)
+}
+
+// GetMigrationVersion returns the highest goose version that is currently
+// applied, ignoring versions that were rolled back.
+func (s Storage) GetMigrationVersion(ctx context.Context) (int64, error) {
+	defer s.observe("GetMigrationVersion", time.Now())
+	query := "SELECT COALESCE(MAX(version_id), 0) FROM (" +
+		"SELECT DISTINCT ON (version_id) version_id, is_applied FROM goose_db_version ORDER BY version_id, id DESC" +
+		") AS latest WHERE is_applied"
+	var version int64
+	err := s.q.QueryRowContext(ctx, query).Scan(&version)
+	return version, err
+}
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"backend/entities"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+const idempotencyKeyColumns = "key, fingerprint, status_code, content_type, response, created_at, expires_at"
+
+func scanIdempotencyKey(row rowScanner) (entities.IdempotencyKey, error) {
+	var key entities.IdempotencyKey
+	err := row.Scan(
+		&key.Key,
+		&key.Fingerprint,
+		&key.StatusCode,
+		&key.ContentType,
+		&key.Response,
+		&key.CreatedAt,
+		&key.ExpiresAt,
+	)
+	return key, err
+}
+
+// ReserveIdempotencyKey claims key for a new request, taking over an expired
+// record if there is one. A record without a response is taken over once it
+// has been reserved for longer than lockTimeout, so that a request that died
+// midway doesn't hold the key until it expires. When the key is already held
+// it returns the existing record and false.
+func (s Storage) ReserveIdempotencyKey(
+	ctx context.Context,
+	key string,
+	fingerprint string,
+	ttl time.Duration,
+	lockTimeout time.Duration,
+) (entities.IdempotencyKey, bool, error) {
+	defer s.observe("ReserveIdempotencyKey", time.Now())
+	now := time.Now().UTC()
+	query := "INSERT INTO idempotency_key (key, fingerprint, created_at, expires_at) VALUES ($1, $2, $3, $4) " +
+		"ON CONFLICT (key) DO UPDATE SET fingerprint=EXCLUDED.fingerprint, status_code=NULL, content_type=NULL, " +
+		"response=NULL, created_at=EXCLUDED.created_at, expires_at=EXCLUDED.expires_at " +
+		"WHERE idempotency_key.expires_at <= EXCLUDED.created_at " +
+		"OR (idempotency_key.status_code IS NULL AND idempotency_key.created_at <= $5) " +
+		"RETURNING " + idempotencyKeyColumns
+	reserved, err := scanIdempotencyKey(s.q.QueryRowContext(ctx, query, key, fingerprint, now, now.Add(ttl), now.Add(-lockTimeout)))
+	if err == nil {
+		return reserved, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return entities.IdempotencyKey{}, false, err
+	}
+	query = "SELECT " + idempotencyKeyColumns + " FROM idempotency_key WHERE key=$1"
+	existing, err := scanIdempotencyKey(s.q.QueryRowContext(ctx, query, key))
+	if err != nil {
+		return entities.IdempotencyKey{}, false, err
+	}
+	return existing, false, nil
+}
+
+func (s Storage) SaveIdempotentResponse(
+	ctx context.Context,
+	key string,
+	statusCode int,
+	contentType string,
+	response []byte,
+) error {
+	defer s.observe("SaveIdempotentResponse", time.Now())
+	query := "UPDATE idempotency_key SET status_code=$2, content_type=$3, response=$4 WHERE key=$1"
+	_, err := s.q.ExecContext(ctx, query, key, statusCode, contentType, response)
+	return err
+}
+
+func (s Storage) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	defer s.observe("DeleteIdempotencyKey", time.Now())
+	_, err := s.q.ExecContext(ctx, "DELETE FROM idempotency_key WHERE key=$1", key)
+	return err
+}
+
+func (s Storage) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	defer s.observe("DeleteExpiredIdempotencyKeys", time.Now())
+	result, err := s.q.ExecContext(ctx, "DELETE FROM idempotency_key WHERE expires_at <= $1", now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"backend/entities"
+	"context"
+	"time"
+)
+
+const notificationColumns = "id, user_id, kind, title, body, tender_id, bid_id, read_at, created_at"
+
+func (s Storage) CreateNotification(ctx context.Context, notification entities.Notification) (entities.Notification, error) {
+	defer s.observe("CreateNotification", time.Now())
+	query := "INSERT INTO notification (user_id, kind, title, body, tender_id, bid_id, created_at) " +
+		"VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id"
+	notification.CreatedAt = time.Now().UTC()
+	err := s.q.QueryRowContext(ctx,
+		query,
+		notification.UserId,
+		notification.Kind,
+		notification.Title,
+		notification.Body,
+		notification.TenderId,
+		notification.BidId,
+		notification.CreatedAt,
+	).Scan(&notification.Id)
+	if err != nil {
+		return entities.Notification{}, err
+	}
+	return notification, nil
+}
+
+func (s Storage) GetNotifications(
+	ctx context.Context,
+	userId string,
+	unreadOnly bool,
+	limit int,
+	offset int,
+) ([]entities.Notification, error) {
+	defer s.observe("GetNotifications", time.Now())
+	query := "SELECT " + notificationColumns + " FROM notification WHERE user_id=$1"
+	if unreadOnly {
+		query += " AND read_at IS NULL"
+	}
+	query += " ORDER BY created_at DESC LIMIT $2 OFFSET $3"
+	rows, err := s.q.QueryContext(ctx, query, userId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	notifications := make([]entities.Notification, 0)
+	for rows.Next() {
+		var notification entities.Notification
+		err := rows.Scan(
+			&notification.Id,
+			&notification.UserId,
+			&notification.Kind,
+			&notification.Title,
+			&notification.Body,
+			&notification.TenderId,
+			&notification.BidId,
+			&notification.ReadAt,
+			&notification.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
+
+func (s Storage) MarkNotificationRead(ctx context.Context, id string, userId string) (bool, error) {
+	defer s.observe("MarkNotificationRead", time.Now())
+	query := "UPDATE notification SET read_at=COALESCE(read_at, $3) WHERE id=$1 AND user_id=$2"
+	result, err := s.q.ExecContext(ctx, query, id, userId, time.Now().UTC())
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (s Storage) GetNotificationPreference(ctx context.Context, userId string) (entities.NotificationPreference, error) {
+	defer s.observe("GetNotificationPreference", time.Now())
+	query := "SELECT user_id, language, email_enabled, in_app_enabled, updated_at " +
+		"FROM notification_preference WHERE user_id=$1"
+	var preference entities.NotificationPreference
+	err := s.q.QueryRowContext(ctx, query, userId).Scan(
+		&preference.UserId,
+		&preference.Language,
+		&preference.EmailEnabled,
+		&preference.InAppEnabled,
+		&preference.UpdatedAt,
+	)
+	return preference, err
+}
+
+func (s Storage) SetNotificationPreference(ctx context.Context, preference entities.NotificationPreference) (entities.NotificationPreference, error) {
+	defer s.observe("SetNotificationPreference", time.Now())
+	query := "INSERT INTO notification_preference (user_id, language, email_enabled, in_app_enabled, updated_at) " +
+		"VALUES ($1, $2, $3, $4, $5) ON CONFLICT (user_id) DO UPDATE SET language=$2, email_enabled=$3, " +
+		"in_app_enabled=$4, updated_at=$5"
+	preference.UpdatedAt = time.Now().UTC()
+	_, err := s.q.ExecContext(ctx,
+		query,
+		preference.UserId,
+		preference.Language,
+		preference.EmailEnabled,
+		preference.InAppEnabled,
+		preference.UpdatedAt,
+	)
+	if err != nil {
+		return entities.NotificationPreference{}, err
+	}
+	return preference, nil
+}
+
+func (s Storage) GetOrganizationResponsibleIds(ctx context.Context, organizationId string) ([]string, error) {
+	defer s.observe("GetOrganizationResponsibleIds", time.Now())
+	query := "SELECT user_id FROM organization_responsible WHERE organization_id=$1"
+	rows, err := s.q.QueryContext(ctx, query, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (s Storage) GetTendersWithApproachingDeadline(ctx context.Context, until time.Time) ([]entities.Tender, error) {
+	defer s.observe("GetTendersWithApproachingDeadline", time.Now())
+	query := "SELECT " + tenderColumns + " FROM tender " +
+		"WHERE status='Published' AND NOT deadline_notified AND deadline IS NOT NULL AND deadline>$1 AND deadline<=$2"
+	rows, err := s.q.QueryContext(ctx, query, time.Now().UTC(), until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanTenders(rows)
+}
+
+func (s Storage) MarkDeadlineNotified(ctx context.Context, tenderId string) error {
+	defer s.observe("MarkDeadlineNotified", time.Now())
+	query := "UPDATE tender SET deadline_notified=TRUE WHERE id=$1"
+	_, err := s.q.ExecContext(ctx, query, tenderId)
+	return err
+}
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"backend/entities"
+	"backend/entities/qualification_status"
+	"context"
+	"database/sql"
+	"time"
+)
+
+const qualificationColumns = "id, supplier_type, supplier_id, organization_id, service_type, description, status, " +
+	"expires_at, reviewed_by, created_at, updated_at"
+
+func scanQualification(row rowScanner) (entities.Qualification, error) {
+	var qualification entities.Qualification
+	err := row.Scan(
+		&qualification.Id,
+		&qualification.SupplierType,
+		&qualification.SupplierId,
+		&qualification.OrganizationId,
+		&qualification.ServiceType,
+		&qualification.Description,
+		&qualification.Status,
+		&qualification.ExpiresAt,
+		&qualification.ReviewedBy,
+		&qualification.CreatedAt,
+		&qualification.UpdatedAt,
+	)
+	return qualification, err
+}
+
+func scanQualifications(rows *sql.Rows) ([]entities.Qualification, error) {
+	qualifications := make([]entities.Qualification, 0)
+	for rows.Next() {
+		qualification, err := scanQualification(rows)
+		if err != nil {
+			return nil, err
+		}
+		qualifications = append(qualifications, qualification)
+	}
+	return qualifications, rows.Err()
+}
+
+func (s Storage) CreateQualification(ctx context.Context, qualification entities.Qualification) (entities.Qualification, error) {
+	defer s.observe("CreateQualification", time.Now())
+	query := "INSERT INTO qualification " +
+		"(supplier_type, supplier_id, organization_id, service_type, description, status, created_at, updated_at) " +
+		"VALUES ($1, $2, $3, $4, $5, 'Pending', $6, $6) RETURNING id"
+	creationTime := time.Now().UTC()
+	err := s.q.QueryRowContext(ctx,
+		query,
+		qualification.SupplierType,
+		qualification.SupplierId,
+		qualification.OrganizationId,
+		qualification.ServiceType,
+		qualification.Description,
+		creationTime,
+	).Scan(&qualification.Id)
+	if err != nil {
+		return entities.Qualification{}, err
+	}
+	qualification.Status = qualification_status.PENDING
+	qualification.CreatedAt = creationTime
+	qualification.UpdatedAt = creationTime
+	return qualification, nil
+}
+
+func (s Storage) GetQualification(ctx context.Context, id string) (entities.Qualification, error) {
+	defer s.observe("GetQualification", time.Now())
+	query := "SELECT " + qualificationColumns + " FROM qualification WHERE id=$1"
+	return scanQualification(s.q.QueryRowContext(ctx, query, id))
+}
+
+func (s Storage) GetMyQualifications(
+	ctx context.Context,
+	userId string,
+	status string,
+	limit int,
+	offset int,
+) ([]entities.Qualification, error) {
+	defer s.observe("GetMyQualifications", time.Now())
+	query := "SELECT " + qualificationColumns + " FROM qualification " +
+		"WHERE ((supplier_type='User' AND supplier_id=$1) OR (supplier_type='Organization' AND supplier_id IN " +
+		"(SELECT organization_id FROM organization_responsible WHERE user_id=$1)))"
+	args := []any{userId, limit, offset}
+	if len(status) > 0 {
+		query += " AND status=$4"
+		args = append(args, status)
+	}
+	query += " ORDER BY created_at DESC LIMIT $2 OFFSET $3"
+	rows, err := s.q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanQualifications(rows)
+}
+
+func (s Storage) GetQualificationsToReview(
+	ctx context.Context,
+	userId string,
+	status string,
+	limit int,
+	offset int,
+) ([]entities.Qualification, error) {
+	defer s.observe("GetQualificationsToReview", time.Now())
+	query := "SELECT " + qualificationColumns + " FROM qualification " +
+		"WHERE organization_id IN (SELECT organization_id FROM organization_responsible WHERE user_id=$1) " +
+		"AND status=$2 ORDER BY created_at LIMIT $3 OFFSET $4"
+	rows, err := s.q.QueryContext(ctx, query, userId, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanQualifications(rows)
+}
+
+func (s Storage) ReviewQualification(
+	ctx context.Context,
+	id string,
+	userId string,
+	status string,
+	expiresAt *time.Time,
+) (entities.Qualification, error) {
+	defer s.observe("ReviewQualification", time.Now())
+	return inTx(ctx, s, func(tx *Storage) (entities.Qualification, error) {
+		query := "UPDATE qualification SET status=$2, reviewed_by=$3, expires_at=$4, updated_at=$5 WHERE id=$1"
+		_, err := tx.q.ExecContext(ctx, query, id, status, userId, expiresAt, time.Now().UTC())
+		if err != nil {
+			return entities.Qualification{}, err
+		}
+		return tx.GetQualification(ctx, id)
+	})
+}
+
+func (s Storage) HasValidQualification(
+	ctx context.Context,
+	supplierType string,
+	supplierId string,
+	organizationId string,
+	serviceType string,
+) (bool, error) {
+	defer s.observe("HasValidQualification", time.Now())
+	query := "SELECT COUNT(*) FROM qualification " +
+		"WHERE supplier_type=$1 AND supplier_id=$2 AND organization_id=$3 AND service_type=$4 " +
+		"AND status='Approved' AND (expires_at IS NULL OR expires_at>$5)"
+	var count int
+	err := s.q.QueryRowContext(ctx, query, supplierType, supplierId, organizationId, serviceType, time.Now().UTC()).Scan(&count)
+	return count > 0, err
+}
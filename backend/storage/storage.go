@@ -3,99 +3,201 @@ package storage
 import (
 	"backend/config"
 	"backend/entities"
-	"backend/entities/decision"
+	"backend/entities/bid_status"
+	"backend/entities/tender_status"
+	"backend/events"
+	"backend/metrics"
+	"backend/tracing"
+	"context"
 	"database/sql"
 	"fmt"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/trace"
 	"time"
 )
 
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 type Storage struct {
-	db *sql.DB
+	db      *sql.DB
+	q       queryer
+	hub     *events.Hub
+	pending *[]events.Event
+	tx      txConfig
+	metrics *metrics.Metrics
+	tracer  trace.Tracer
 }
 
-func NewStorage(cfg *config.Config) *Storage {
-	return &Storage{db: cfg.GetDB()}
+type rowScanner interface {
+	Scan(dest ...any) error
 }
 
-func (s Storage) CreateTender(
-	name string,
-	description string,
-	serviceType []string,
-	organizationId string,
-) (entities.Tender, error) {
+const tenderColumns = "id, name, description, status, service_type, version, created_at, updated_at, organization_id, " +
+	"deadline, require_qualification, budget, currency, budget_public, reject_over_budget, auction_starts_at, " +
+	"auction_ends_at, auction_extension_seconds"
+
+func scanTender(row rowScanner) (entities.Tender, error) {
+	var tender entities.Tender
+	err := row.Scan(
+		&tender.Id,
+		&tender.Name,
+		&tender.Description,
+		&tender.Status,
+		pq.Array(&tender.ServiceType),
+		&tender.Version,
+		&tender.CreatedAt,
+		&tender.UpdatedAt,
+		&tender.OrganizationId,
+		&tender.Deadline,
+		&tender.RequireQualification,
+		&tender.Budget,
+		&tender.Currency,
+		&tender.BudgetPublic,
+		&tender.RejectOverBudget,
+		&tender.AuctionStartsAt,
+		&tender.AuctionEndsAt,
+		&tender.AuctionExtension,
+	)
+	return tender, err
+}
+
+func scanTenders(rows *sql.Rows) ([]entities.Tender, error) {
+	tenders := make([]entities.Tender, 0)
+	for rows.Next() {
+		tender, err := scanTender(rows)
+		if err != nil {
+			return nil, err
+		}
+		tenders = append(tenders, tender)
+	}
+	return tenders, rows.Err()
+}
+
+const bidColumns = "id, tender_id, name, description, status, author_type, author_id, version, created_at, updated_at, " +
+	"withdrawal_reason, withdrawn_by, withdrawn_at, conflict_of_interest, amount, auction_rank"
+
+func scanBid(row rowScanner) (entities.Bid, error) {
+	var bid entities.Bid
+	err := row.Scan(
+		&bid.Id,
+		&bid.TenderId,
+		&bid.Name,
+		&bid.Description,
+		&bid.Status,
+		&bid.AuthorType,
+		&bid.AuthorId,
+		&bid.Version,
+		&bid.CreatedAt,
+		&bid.UpdatedAt,
+		&bid.WithdrawalReason,
+		&bid.WithdrawnBy,
+		&bid.WithdrawnAt,
+		&bid.ConflictOfInterest,
+		&bid.Amount,
+		&bid.AuctionRank,
+	)
+	return bid, err
+}
+
+func scanBids(rows *sql.Rows) ([]entities.Bid, error) {
+	bids := make([]entities.Bid, 0)
+	for rows.Next() {
+		bid, err := scanBid(rows)
+		if err != nil {
+			return nil, err
+		}
+		bids = append(bids, bid)
+	}
+	return bids, rows.Err()
+}
+
+func NewStorage(db *sql.DB, cfg *config.Config, hub *events.Hub, m *metrics.Metrics, t *tracing.Tracing) *Storage {
+	tracer := t.Tracer("backend/storage")
+	dbConfig := cfg.GetDBConfig()
+	return &Storage{
+		db:  db,
+		q:   tracedQueryer{q: db, tracer: tracer},
+		hub: hub,
+		tx: txConfig{
+			options:    sql.TxOptions{Isolation: dbConfig.GetTxIsolation()},
+			maxRetries: dbConfig.GetTxMaxRetries(),
+			backoff:    dbConfig.GetTxRetryBackoff(),
+		},
+		metrics: m,
+		tracer:  tracer,
+	}
+}
+
+func (s Storage) observe(method string, start time.Time) {
+	s.metrics.ObserveStorage(method, time.Since(start))
+}
+
+func (s Storage) CreateTender(ctx context.Context, tender entities.Tender) (entities.Tender, error) {
+	defer s.observe("CreateTender", time.Now())
 	query := "INSERT INTO tender " +
-		"(name, description, service_type, organization_id, status, version, created_at, updated_at)" +
-		" VALUES ($1, $2, $3, $4, 'Created', 1, $5, $5) RETURNING id"
-	var insertedId string
+		"(name, description, service_type, organization_id, status, version, created_at, updated_at, deadline, " +
+		"require_qualification, budget, currency, budget_public, reject_over_budget, auction_starts_at, auction_ends_at, " +
+		"auction_extension_seconds) " +
+		"VALUES ($1, $2, $3, $4, 'Created', 1, $5, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id"
 	creationTime := time.Now().UTC()
-	err := s.db.QueryRow(
+	err := s.q.QueryRowContext(ctx,
 		query,
-		name,
-		description,
-		serviceType,
-		organizationId,
+		tender.Name,
+		tender.Description,
+		tender.ServiceType,
+		tender.OrganizationId,
 		creationTime,
-	).Scan(&insertedId)
+		tender.Deadline,
+		tender.RequireQualification,
+		tender.Budget,
+		tender.Currency,
+		tender.BudgetPublic,
+		tender.RejectOverBudget,
+		tender.AuctionStartsAt,
+		tender.AuctionEndsAt,
+		tender.AuctionExtension,
+	).Scan(&tender.Id)
 	if err != nil {
 		return entities.Tender{}, err
 	}
-	return entities.Tender{
-		Id:             insertedId,
-		Name:           name,
-		Description:    description,
-		ServiceType:    serviceType,
-		OrganizationId: organizationId,
-		CreatedAt:      creationTime,
-		UpdatedAt:      creationTime,
-		Status:         "Created",
-		Version:        1,
-	}, nil
+	tender.Status = tender_status.CREATED
+	tender.Version = 1
+	tender.CreatedAt = creationTime
+	tender.UpdatedAt = creationTime
+	s.publishTender(events.TENDER_CREATED, tender)
+	return tender, nil
 }
 
 func (s Storage) FilterTenders(
+	ctx context.Context,
 	limit int,
 	offset int,
 	serviceType []string,
 ) ([]entities.Tender, error) {
+	defer s.observe("FilterTenders", time.Now())
 	filters := "status='Published'"
 	for _, item := range serviceType {
 		filters += fmt.Sprintf("AND '%s'=ANY(service_type)", item)
 	}
-	query := "SELECT id, name, description, status, service_type, version, created_at, updated_at, organization_id " +
-		"FROM tender WHERE " + filters + " ORDER BY name OFFSET $1 LIMIT $2"
-	rows, err := s.db.Query(query, offset, limit)
-	defer rows.Close()
+	query := "SELECT " + tenderColumns + " FROM tender WHERE " + filters + " ORDER BY name OFFSET $1 LIMIT $2"
+	rows, err := s.q.QueryContext(ctx, query, offset, limit)
 	if err != nil {
 		return nil, err
 	}
-	tenders := make([]entities.Tender, 0)
-	for rows.Next() {
-		var tender entities.Tender
-		err := rows.Scan(
-			&tender.Id,
-			&tender.Name,
-			&tender.Description,
-			&tender.Status,
-			pq.Array(&tender.ServiceType),
-			&tender.Version,
-			&tender.CreatedAt,
-			&tender.UpdatedAt,
-			&tender.OrganizationId,
-		)
-		if err != nil {
-			return nil, err
-		}
-		tenders = append(tenders, tender)
-	}
-	return tenders, nil
+	defer rows.Close()
+	return scanTenders(rows)
 }
 
-func (s Storage) GetUserId(username string) (string, error) {
+func (s Storage) GetUserId(ctx context.Context, username string) (string, error) {
+	defer s.observe("GetUserId", time.Now())
 	query := "SELECT id FROM employee WHERE username=$1"
 	var id string
-	err := s.db.QueryRow(query, username).Scan(&id)
+	err := s.q.QueryRowContext(ctx, query, username).Scan(&id)
 	if err != nil {
 		return "", err
 	}
@@ -103,139 +205,157 @@ func (s Storage) GetUserId(username string) (string, error) {
 }
 
 func (s Storage) FilterUsersTenders(
+	ctx context.Context,
 	limit int,
 	offset int,
 	userId string,
 ) ([]entities.Tender, error) {
-	query := `
-SELECT
-	t.id,
-	t.name,
-	t.description,
-	t.status,
-	t.service_type,
-	t.version,
-	t.created_at,
-	t.updated_at,
-	t.organization_id
-FROM tender AS t
-JOIN organization_responsible AS o
-ON t.organization_id=o.organization_id
-WHERE o.user_id=$1
-ORDER BY t.id
-OFFSET $2
-LIMIT $3
-	`
-	rows, err := s.db.Query(query, userId, offset, limit)
-	defer rows.Close()
+	defer s.observe("FilterUsersTenders", time.Now())
+	query := "SELECT " + tenderColumns + " FROM tender " +
+		"WHERE organization_id IN (SELECT organization_id FROM organization_responsible WHERE user_id=$1) " +
+		"ORDER BY id OFFSET $2 LIMIT $3"
+	rows, err := s.q.QueryContext(ctx, query, userId, offset, limit)
 	if err != nil {
 		return nil, err
 	}
-	tenders := make([]entities.Tender, 0)
-	for rows.Next() {
-		var tender entities.Tender
-		err := rows.Scan(
-			&tender.Id,
-			&tender.Name,
-			&tender.Description,
-			&tender.Status,
-			pq.Array(&tender.ServiceType),
-			&tender.Version,
-			&tender.CreatedAt,
-			&tender.UpdatedAt,
-			&tender.OrganizationId,
-		)
-		if err != nil {
-			return nil, err
-		}
-		tenders = append(tenders, tender)
-	}
-	return tenders, nil
+	defer rows.Close()
+	return scanTenders(rows)
 }
 
 func (s Storage) CheckOrganizationResponsible(
+	ctx context.Context,
 	userId string,
 	organizationId string,
 ) (bool, error) {
+	defer s.observe("CheckOrganizationResponsible", time.Now())
 	query := "SELECT COUNT(*) FROM organization_responsible WHERE user_id=$1 AND organization_id=$2"
 	var count int
-	err := s.db.QueryRow(query, userId, organizationId).Scan(&count)
+	err := s.q.QueryRowContext(ctx, query, userId, organizationId).Scan(&count)
 	return count > 0, err
 }
 
-func (s Storage) GetTender(id string) (entities.Tender, error) {
-	query := "SELECT name, description, status, service_type, version, created_at, updated_at, organization_id " +
-		"FROM tender WHERE id=$1"
-	var tender entities.Tender
-	tender.Id = id
-	err := s.db.QueryRow(query, id).Scan(
-		&tender.Name,
-		&tender.Description,
-		&tender.Status,
-		pq.Array(&tender.ServiceType),
-		&tender.Version,
-		&tender.CreatedAt,
-		&tender.UpdatedAt,
-		&tender.OrganizationId,
-	)
-	return tender, err
+func (s Storage) GetTender(ctx context.Context, id string) (entities.Tender, error) {
+	defer s.observe("GetTender", time.Now())
+	query := "SELECT " + tenderColumns + " FROM tender WHERE id=$1"
+	return scanTender(s.q.QueryRowContext(ctx, query, id))
 }
 
-func (s Storage) PatchTender(
-	id string,
-	name *string,
-	description *string,
-	status *string,
-	serviceType []string,
-) (entities.Tender, error) {
-	if name == nil && description == nil && status == nil && serviceType == nil {
-		return s.GetTender(id)
-	}
-	tender, err := s.GetTender(id)
-	if err != nil {
-		return tender, err
-	}
-	query := sq.Update("tender")
-	query = query.Set("version", tender.Version+1)
-	query = query.Set("updated_at", time.Now().UTC())
-	if name != nil {
-		query = query.Set("name", name)
-	}
-	if description != nil {
-		query = query.Set("description", description)
-	}
-	if status != nil {
-		query = query.Set("status", status)
-	}
-	if serviceType != nil {
-		query = query.Set("service_type", serviceType)
-	}
-	query = query.Where(sq.Eq{"id": id}).PlaceholderFormat(sq.Dollar)
-	sqlQuery, args, err := query.ToSql()
-	if err != nil {
-		return entities.Tender{}, err
-	}
-	_, err = s.db.Exec(sqlQuery, args...)
-	if err != nil {
-		return entities.Tender{}, err
-	}
-	return s.GetTender(id)
+type TenderPatch struct {
+	Name                 *string
+	Description          *string
+	Status               *string
+	ServiceType          []string
+	Deadline             *time.Time
+	RequireQualification *bool
+	Budget               *float64
+	Currency             *string
+	BudgetPublic         *bool
+	RejectOverBudget     *bool
+	AuctionStartsAt      *time.Time
+	AuctionEndsAt        *time.Time
+	AuctionExtension     *int
+}
+
+func (s Storage) PatchTender(ctx context.Context, id string, patch TenderPatch) (entities.Tender, error) {
+	defer s.observe("PatchTender", time.Now())
+	return inTx(ctx, s, func(tx *Storage) (entities.Tender, error) {
+		tender, err := tx.GetTender(ctx, id)
+		if err != nil {
+			return tender, err
+		}
+		query := sq.Update("tender")
+		changed := false
+		if patch.Name != nil {
+			query = query.Set("name", patch.Name)
+			changed = true
+		}
+		if patch.Description != nil {
+			query = query.Set("description", patch.Description)
+			changed = true
+		}
+		if patch.Status != nil {
+			query = query.Set("status", patch.Status)
+			changed = true
+		}
+		if patch.ServiceType != nil {
+			query = query.Set("service_type", patch.ServiceType)
+			changed = true
+		}
+		if patch.Deadline != nil {
+			query = query.Set("deadline", patch.Deadline).Set("deadline_notified", false)
+			changed = true
+		}
+		if patch.RequireQualification != nil {
+			query = query.Set("require_qualification", patch.RequireQualification)
+			changed = true
+		}
+		if patch.Budget != nil {
+			query = query.Set("budget", patch.Budget)
+			changed = true
+		}
+		if patch.Currency != nil {
+			query = query.Set("currency", patch.Currency)
+			changed = true
+		}
+		if patch.BudgetPublic != nil {
+			query = query.Set("budget_public", patch.BudgetPublic)
+			changed = true
+		}
+		if patch.RejectOverBudget != nil {
+			query = query.Set("reject_over_budget", patch.RejectOverBudget)
+			changed = true
+		}
+		if patch.AuctionStartsAt != nil {
+			query = query.Set("auction_starts_at", patch.AuctionStartsAt)
+			changed = true
+		}
+		if patch.AuctionEndsAt != nil {
+			query = query.Set("auction_ends_at", patch.AuctionEndsAt)
+			changed = true
+		}
+		if patch.AuctionExtension != nil {
+			query = query.Set("auction_extension_seconds", patch.AuctionExtension)
+			changed = true
+		}
+		if !changed {
+			return tender, nil
+		}
+		query = query.Set("version", tender.Version+1)
+		query = query.Set("updated_at", time.Now().UTC())
+		query = query.Where(sq.Eq{"id": id}).PlaceholderFormat(sq.Dollar)
+		sqlQuery, args, err := query.ToSql()
+		if err != nil {
+			return entities.Tender{}, err
+		}
+		_, err = tx.q.ExecContext(ctx, sqlQuery, args...)
+		if err != nil {
+			return entities.Tender{}, err
+		}
+		tender, err = tx.GetTender(ctx, id)
+		if err != nil {
+			return entities.Tender{}, err
+		}
+		tx.publishTender(events.TENDER_UPDATED, tender)
+		return tender, nil
+	})
 }
 
 func pointerToSQLNullString(s *string) sql.NullString {
 	if s == nil {
-		return sql.NullString{"", false}
+		return sql.NullString{String: "", Valid: false}
 	}
-	return sql.NullString{*s, true}
+	return sql.NullString{String: *s, Valid: true}
 }
 
-func (s Storage) GetOrganization(id string) (entities.Organization, error) {
-	query := "SELECT name, description, type, created_at, updated_at FROM organization WHERE id=$1"
+func (s Storage) GetOrganization(ctx context.Context, id string) (entities.Organization, error) {
+	defer s.observe("GetOrganization", time.Now())
+	query := "SELECT name, description, type, conflict_policy, created_at, updated_at FROM organization WHERE id=$1"
 	var org entities.Organization
-	err := s.db.QueryRow(query, id).Scan(
+	err := s.q.QueryRowContext(ctx, query, id).Scan(
 		&org.Name,
 		&org.Description,
 		&org.Type,
+		&org.ConflictPolicy,
 		&org.CreatedAt,
 		&org.UpdatedAt,
 	)
@@ -246,13 +366,49 @@ func (s Storage) GetOrganization(id string) (entities.Organization, error) {
 	return org, nil
 }
 
-func (s Storage) GetUser(id string) (entities.Employee, error) {
-	query := "SELECT username, first_name, last_name, created_at, updated_at FROM employee WHERE id=$1"
+func (s Storage) SetConflictPolicy(ctx context.Context, organizationId string, policy string) (entities.Organization, error) {
+	defer s.observe("SetConflictPolicy", time.Now())
+	return inTx(ctx, s, func(tx *Storage) (entities.Organization, error) {
+		query := "UPDATE organization SET conflict_policy=$2, updated_at=$3 WHERE id=$1"
+		_, err := tx.q.ExecContext(ctx, query, organizationId, policy, time.Now().UTC())
+		if err != nil {
+			return entities.Organization{}, err
+		}
+		return tx.GetOrganization(ctx, organizationId)
+	})
+}
+
+func (s Storage) GetSharedResponsibles(ctx context.Context, organizationId string, otherOrganizationId string) ([]string, error) {
+	defer s.observe("GetSharedResponsibles", time.Now())
+	query := "SELECT e.username FROM employee AS e " +
+		"JOIN organization_responsible AS a ON a.user_id=e.id AND a.organization_id=$1 " +
+		"JOIN organization_responsible AS b ON b.user_id=e.id AND b.organization_id=$2 " +
+		"ORDER BY e.username"
+	rows, err := s.q.QueryContext(ctx, query, organizationId, otherOrganizationId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	usernames := make([]string, 0)
+	for rows.Next() {
+		var username string
+		if err := rows.Scan(&username); err != nil {
+			return nil, err
+		}
+		usernames = append(usernames, username)
+	}
+	return usernames, rows.Err()
+}
+
+func (s Storage) GetUser(ctx context.Context, id string) (entities.Employee, error) {
+	defer s.observe("GetUser", time.Now())
+	query := "SELECT username, first_name, last_name, email, created_at, updated_at FROM employee WHERE id=$1"
 	var user entities.Employee
-	err := s.db.QueryRow(query, id).Scan(
+	err := s.q.QueryRowContext(ctx, query, id).Scan(
 		&user.Username,
 		&user.FirstName,
 		&user.LastName,
+		&user.Email,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -263,193 +419,213 @@ func (s Storage) GetUser(id string) (entities.Employee, error) {
 	return user, nil
 }
 
-func (s Storage) CreateBid(
-	name string,
-	description string,
-	authorType string,
-	authorId string,
-	tenderId string,
-) (entities.Bid, error) {
-	query := "INSERT INTO bid (name, description, status, author_type, author_id, version, created_at, updated_at, tender_id) " +
-		"VALUES ($1, $2, 'Created', $3, $4, 1, $5, $6, $7) RETURNING id"
-	var insertedId string
+func (s Storage) CreateBid(ctx context.Context, bid entities.Bid) (entities.Bid, error) {
+	defer s.observe("CreateBid", time.Now())
+	query := "INSERT INTO bid " +
+		"(name, description, status, author_type, author_id, version, created_at, updated_at, tender_id, " +
+		"conflict_of_interest, amount) VALUES ($1, $2, 'Created', $3, $4, 1, $5, $5, $6, $7, $8) RETURNING id"
 	creationTime := time.Now().UTC()
-	err := s.db.QueryRow(
+	err := s.q.QueryRowContext(ctx,
 		query,
-		name,
-		description,
-		authorType,
-		authorId,
+		bid.Name,
+		bid.Description,
+		bid.AuthorType,
+		bid.AuthorId,
 		creationTime,
-		creationTime,
-		tenderId,
-	).Scan(&insertedId)
+		bid.TenderId,
+		bid.ConflictOfInterest,
+		bid.Amount,
+	).Scan(&bid.Id)
 	if err != nil {
 		return entities.Bid{}, err
 	}
-	return entities.Bid{
-		Id:          insertedId,
-		TenderId:    tenderId,
-		Name:        name,
-		Description: description,
-		Status:      "Created",
-		AuthorType:  authorType,
-		AuthorId:    authorId,
-		Version:     1,
-		CreatedAt:   creationTime,
-		UpdatedAt:   creationTime,
-	}, nil
+	bid.Status = bid_status.CREATED
+	bid.Version = 1
+	bid.CreatedAt = creationTime
+	bid.UpdatedAt = creationTime
+	s.publishBid(ctx, events.BID_CREATED, bid)
+	return bid, nil
 }
 
 func (s Storage) GetMyBids(
+	ctx context.Context,
 	userId string,
 	limit int,
 	offset int,
 ) ([]entities.Bid, error) {
-	query := "SELECT id, tender_id, name, description, status, version, created_at, updated_at " +
-		"FROM bid WHERE author_type='User' AND author_id=$1 ORDER BY name LIMIT $2 OFFSET $3"
-	rows, err := s.db.Query(query, userId, limit, offset)
-	defer rows.Close()
+	defer s.observe("GetMyBids", time.Now())
+	query := "SELECT " + bidColumns + " FROM bid " +
+		"WHERE (author_type='User' AND author_id=$1) OR (author_type='Organization' AND author_id IN " +
+		"(SELECT organization_id FROM organization_responsible WHERE user_id=$1)) " +
+		"ORDER BY name LIMIT $2 OFFSET $3"
+	rows, err := s.q.QueryContext(ctx, query, userId, limit, offset)
 	if err != nil {
 		return nil, err
 	}
-	bids := make([]entities.Bid, 0)
-	for rows.Next() {
-		var bid entities.Bid
-		err := rows.Scan(
-			&bid.Id,
-			&bid.TenderId,
-			&bid.Name,
-			&bid.Description,
-			&bid.Status,
-			&bid.Version,
-			&bid.CreatedAt,
-			&bid.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		bid.AuthorId = userId
-		bid.AuthorType = "User"
-		bids = append(bids, bid)
-	}
-	return bids, nil
+	defer rows.Close()
+	return scanBids(rows)
 }
 
 func (s Storage) GetBidsByTender(
+	ctx context.Context,
 	tenderId string,
 	limit int,
 	offset int,
+	includeWithdrawn bool,
 ) ([]entities.Bid, error) {
-	query := "SELECT id, name, description, status, version, author_type, author_id, created_at, updated_at " +
-		"FROM bid WHERE tender_id=$1 ORDER BY name LIMIT $2 OFFSET $3"
-	rows, err := s.db.Query(query, tenderId, limit, offset)
-	defer rows.Close()
+	defer s.observe("GetBidsByTender", time.Now())
+	query := "SELECT " + bidColumns + " FROM bid WHERE tender_id=$1"
+	if !includeWithdrawn {
+		query += " AND status<>'Withdrawn'"
+	}
+	query += " ORDER BY name LIMIT $2 OFFSET $3"
+	rows, err := s.q.QueryContext(ctx, query, tenderId, limit, offset)
 	if err != nil {
 		return nil, err
 	}
-	bids := make([]entities.Bid, 0)
-	for rows.Next() {
-		var bid entities.Bid
-		err := rows.Scan(
-			&bid.Id,
-			&bid.Name,
-			&bid.Description,
-			&bid.Status,
-			&bid.Version,
-			&bid.AuthorType,
-			&bid.AuthorId,
-			&bid.CreatedAt,
-			&bid.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		bid.TenderId = tenderId
-		bids = append(bids, bid)
-	}
-	return bids, nil
+	defer rows.Close()
+	return scanBids(rows)
 }
 
-func (s Storage) GetBid(id string) (entities.Bid, error) {
-	query := "SELECT tender_id, name, description, status, author_type, author_id, version, created_at, updated_at " +
-		"FROM bid WHERE id=$1"
-	var bid entities.Bid
-	err := s.db.QueryRow(query, id).Scan(
-		&bid.TenderId,
-		&bid.Name,
-		&bid.Description,
-		&bid.Status,
-		&bid.AuthorType,
-		&bid.AuthorId,
-		&bid.Version,
-		&bid.CreatedAt,
-		&bid.UpdatedAt,
-	)
+func (s Storage) GetBid(ctx context.Context, id string) (entities.Bid, error) {
+	defer s.observe("GetBid", time.Now())
+	query := "SELECT " + bidColumns + " FROM bid WHERE id=$1"
+	bid, err := scanBid(s.q.QueryRowContext(ctx, query, id))
 	if err != nil {
 		return entities.Bid{}, err
 	}
-	bid.Id = id
 	return bid, nil
 }
 
-func (s Storage) PatchBid(
-	id string,
-	name *string,
-	description *string,
-	status *string,
-) (entities.Bid, error) {
-	if name == nil && description == nil && status == nil {
-		return s.GetBid(id)
-	}
-	bid, err := s.GetBid(id)
+type BidPatch struct {
+	Name        *string
+	Description *string
+	Status      *string
+	Amount      *float64
+}
+
+func (s Storage) PatchBid(ctx context.Context, id string, patch BidPatch) (entities.Bid, error) {
+	defer s.observe("PatchBid", time.Now())
+	return inTx(ctx, s, func(tx *Storage) (entities.Bid, error) {
+		bid, err := tx.GetBid(ctx, id)
+		if err != nil {
+			return bid, err
+		}
+		query := sq.Update("bid")
+		changed := false
+		if patch.Name != nil {
+			query = query.Set("name", patch.Name)
+			changed = true
+		}
+		if patch.Description != nil {
+			query = query.Set("description", patch.Description)
+			changed = true
+		}
+		if patch.Status != nil {
+			query = query.Set("status", patch.Status)
+			if *patch.Status != bid_status.WITHDRAWN {
+				query = query.Set("withdrawal_reason", nil)
+				query = query.Set("withdrawn_by", nil)
+				query = query.Set("withdrawn_at", nil)
+			}
+			changed = true
+		}
+		if patch.Amount != nil {
+			query = query.Set("amount", patch.Amount)
+			changed = true
+		}
+		if !changed {
+			return bid, nil
+		}
+		query = query.Set("version", bid.Version+1)
+		query = query.Set("updated_at", time.Now().UTC())
+		query = query.Where(sq.Eq{"id": id}).PlaceholderFormat(sq.Dollar)
+		sqlQuery, args, err := query.ToSql()
+		if err != nil {
+			return entities.Bid{}, err
+		}
+		_, err = tx.q.ExecContext(ctx, sqlQuery, args...)
+		if err != nil {
+			return entities.Bid{}, err
+		}
+		bid, err = tx.GetBid(ctx, id)
+		if err != nil {
+			return entities.Bid{}, err
+		}
+		tx.publishBid(ctx, events.BID_UPDATED, bid)
+		return bid, nil
+	})
+}
+
+func (s Storage) WithdrawBid(ctx context.Context, id string, userId string, reason string) (entities.Bid, error) {
+	defer s.observe("WithdrawBid", time.Now())
+	return inTx(ctx, s, func(tx *Storage) (entities.Bid, error) {
+		query := "UPDATE bid SET status='Withdrawn', withdrawal_reason=$2, withdrawn_by=$3, withdrawn_at=$4, " +
+			"version=version+1, updated_at=$4 WHERE id=$1"
+		_, err := tx.q.ExecContext(ctx, query, id, reason, userId, time.Now().UTC())
+		if err != nil {
+			return entities.Bid{}, err
+		}
+		bid, err := tx.GetBid(ctx, id)
+		if err != nil {
+			return entities.Bid{}, err
+		}
+		tx.publishBid(ctx, events.BID_UPDATED, bid)
+		return bid, nil
+	})
+}
+
+func (s Storage) GetDecisionVotes(ctx context.Context, bidId string) ([]entities.DecisionVote, error) {
+	defer s.observe("GetDecisionVotes", time.Now())
+	query := "SELECT user_id, decision, conflict_of_interest, created_at FROM bid_decision " +
+		"WHERE bid_id=$1 ORDER BY created_at"
+	rows, err := s.q.QueryContext(ctx, query, bidId)
 	if err != nil {
-		return bid, err
-	}
-	query := sq.Update("bid")
-	query = query.Set("version", bid.Version+1)
-	query = query.Set("updated_at", time.Now().UTC())
-	if name != nil {
-		query = query.Set("name", name)
-	}
-	if description != nil {
-		query = query.Set("description", description)
-	}
-	if status != nil {
-		query = query.Set("status", status)
+		return nil, err
 	}
-	query = query.Where(sq.Eq{"id": id}).PlaceholderFormat(sq.Dollar)
-	sqlQuery, args, err := query.ToSql()
-	if err != nil {
-		return entities.Bid{}, err
+	defer rows.Close()
+	votes := make([]entities.DecisionVote, 0)
+	for rows.Next() {
+		var vote entities.DecisionVote
+		var userId sql.NullString
+		if err := rows.Scan(&userId, &vote.Decision, &vote.ConflictOfInterest, &vote.CreatedAt); err != nil {
+			return nil, err
+		}
+		if userId.Valid {
+			vote.UserId = &userId.String
+		}
+		votes = append(votes, vote)
 	}
-	_, err = s.db.Exec(sqlQuery, args...)
+	return votes, rows.Err()
+}
+
+func (s Storage) SetDecision(ctx context.Context, bidId string, userId *string, decision string, conflictOfInterest bool) error {
+	defer s.observe("SetDecision", time.Now())
+	query := "INSERT INTO bid_decision (bid_id, user_id, decision, conflict_of_interest, created_at) " +
+		"VALUES ($1, $2, $3, $4, $5)"
+	_, err := s.q.ExecContext(ctx, query, bidId, userId, decision, conflictOfInterest, time.Now().UTC())
 	if err != nil {
-		return entities.Bid{}, err
+		return err
 	}
-	return s.GetBid(id)
+	s.publishDecision(ctx, bidId, decision)
+	return nil
 }
 
-func (s Storage) GetDecision(bidId string) (string, error) {
-	query := "SELECT decision FROM bid_decision WHERE bid_id=$1"
-	rows, err := s.db.Query(query, bidId)
-	defer rows.Close()
+func (s Storage) GetUserOrganizationIds(ctx context.Context, userId string) ([]string, error) {
+	defer s.observe("GetUserOrganizationIds", time.Now())
+	query := "SELECT organization_id FROM organization_responsible WHERE user_id=$1"
+	rows, err := s.q.QueryContext(ctx, query, userId)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	defer rows.Close()
+	ids := make([]string, 0)
 	for rows.Next() {
-		var decision string
-		err := rows.Scan(&decision)
-		if err != nil {
-			return "", err
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
 		}
-		return decision, nil
+		ids = append(ids, id)
 	}
-	return decision.UNKNOWN, nil
-}
-
-func (s Storage) SetDecision(bidId string, decision string) error {
-	query := "INSERT INTO bid_decision (bid_id, decision) VALUES ($1, $2)"
-	_, err := s.db.Exec(query, bidId, decision)
-	return err
+	return ids, rows.Err()
 }
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+type tracedQueryer struct {
+	q      queryer
+	tracer trace.Tracer
+}
+
+// statementName turns a query into a short span name like "SELECT tender".
+func statementName(query string) string {
+	words := strings.Fields(query)
+	if len(words) == 0 {
+		return "SQL"
+	}
+	operation := strings.ToUpper(words[0])
+	marker := ""
+	switch operation {
+	case "SELECT", "DELETE":
+		marker = "FROM"
+	case "INSERT":
+		marker = "INTO"
+	case "UPDATE":
+		if len(words) > 1 {
+			return operation + " " + words[1]
+		}
+	}
+	for i, word := range words[:len(words)-1] {
+		if strings.EqualFold(word, marker) {
+			return operation + " " + strings.Trim(words[i+1], "()")
+		}
+	}
+	return operation
+}
+
+func (t tracedQueryer) start(ctx context.Context, query string) (context.Context, trace.Span) {
+	return t.tracer.Start(ctx, statementName(query),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBQueryText(query)),
+	)
+}
+
+func finish(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (t tracedQueryer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := t.start(ctx, query)
+	result, err := t.q.ExecContext(ctx, query, args...)
+	finish(span, err)
+	return result, err
+}
+
+// QueryContext ends the span once the first rows are available, reading the
+// rest of the result set is not included.
+func (t tracedQueryer) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := t.start(ctx, query)
+	rows, err := t.q.QueryContext(ctx, query, args...)
+	finish(span, err)
+	return rows, err
+}
+
+func (t tracedQueryer) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := t.start(ctx, query)
+	row := t.q.QueryRowContext(ctx, query, args...)
+	finish(span, row.Err())
+	return row
+}
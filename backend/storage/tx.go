@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"backend/events"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jackc/pgconn"
+	"time"
+)
+
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+	queryCanceled        = "57014"
+	invalidTextInput     = "22P02"
+)
+
+type txConfig struct {
+	options    sql.TxOptions
+	maxRetries int
+	backoff    time.Duration
+}
+
+// WithTx runs fn inside a single transaction. Nested calls join the outer
+// transaction. Serialization failures and deadlocks restart fn from scratch up
+// to the configured number of retries, so fn must not have side effects outside
+// of tx. Events are published only after a successful commit.
+func (s Storage) WithTx(ctx context.Context, fn func(tx *Storage) error) error {
+	if s.pending != nil {
+		return fn(&s)
+	}
+	for attempt := 0; ; attempt++ {
+		err := s.runTx(ctx, fn)
+		if err == nil || !isRetryable(err) || attempt >= s.tx.maxRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(s.tx.backoff * time.Duration(attempt+1)):
+		}
+	}
+}
+
+func (s Storage) runTx(ctx context.Context, fn func(tx *Storage) error) error {
+	sqlTx, err := s.db.BeginTx(ctx, &s.tx.options)
+	if err != nil {
+		return err
+	}
+	defer sqlTx.Rollback()
+	pending := make([]events.Event, 0)
+	tx := &Storage{
+		db:      s.db,
+		q:       tracedQueryer{q: sqlTx, tracer: s.tracer},
+		hub:     s.hub,
+		pending: &pending,
+		tx:      s.tx,
+		metrics: s.metrics,
+		tracer:  s.tracer,
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := sqlTx.Commit(); err != nil {
+		return err
+	}
+	for _, event := range pending {
+		s.hub.Publish(event)
+	}
+	return nil
+}
+
+func inTx[T any](ctx context.Context, s Storage, fn func(tx *Storage) (T, error)) (T, error) {
+	var result T
+	err := s.WithTx(ctx, func(tx *Storage) error {
+		var err error
+		result, err = fn(tx)
+		return err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return result, nil
+}
+
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+}
+
+func IsQueryTimeout(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == queryCanceled
+}
+
+// IsInvalidInput reports that postgres couldn't parse a value, e.g. a malformed
+// uuid taken from the request path.
+func IsInvalidInput(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == invalidTextInput
+}
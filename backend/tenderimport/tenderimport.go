@@ -0,0 +1,212 @@
+package tenderimport
+
+import (
+	"backend/service"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/xuri/excelize/v2"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var dateLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// Format guesses the file format from its name.
+func Format(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	}
+	return "", fmt.Errorf("unsupported file %q, expected .csv or .xlsx", filename)
+}
+
+// Parse reads tenders from a CSV or the first sheet of an XLSX file. The
+// first row is a header with createTenderRequest field names, serviceType
+// lists are separated by ";". Cells that can't be parsed become row errors,
+// a malformed file or header is an error.
+func Parse(r io.Reader, format string) ([]service.ImportRow, error) {
+	var records [][]string
+	var err error
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		records, err = reader.ReadAll()
+	case FormatXLSX:
+		records, err = readXLSX(r)
+	default:
+		err = fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("file is empty")
+	}
+	columns, err := mapHeader(records[0])
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]service.ImportRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		if isBlank(record) {
+			continue
+		}
+		rows = append(rows, parseRow(i+2, record, columns, format))
+	}
+	return rows, nil
+}
+
+func readXLSX(r io.Reader) ([][]string, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("workbook has no sheets")
+	}
+	return file.GetRows(sheets[0], excelize.Options{RawCellValue: true})
+}
+
+func isBlank(record []string) bool {
+	for _, cell := range record {
+		if len(strings.TrimSpace(cell)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type column struct {
+	name  string
+	index []int
+}
+
+// mapHeader resolves header cells to CreateTenderParams fields by their JSON
+// names, ignoring case.
+func mapHeader(header []string) ([]column, error) {
+	fields := make(map[string]column)
+	paramsType := reflect.TypeOf(service.CreateTenderParams{})
+	for i := 0; i < paramsType.NumField(); i++ {
+		field := paramsType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		fields[strings.ToLower(name)] = column{name: name, index: field.Index}
+	}
+	columns := make([]column, len(header))
+	seen := make(map[string]bool)
+	for i, cell := range header {
+		key := strings.ToLower(strings.TrimSpace(cell))
+		c, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", cell)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate column %q", cell)
+		}
+		seen[key] = true
+		columns[i] = c
+	}
+	return columns, nil
+}
+
+func parseRow(number int, record []string, columns []column, format string) service.ImportRow {
+	row := service.ImportRow{Row: number}
+	params := reflect.ValueOf(&row.Params).Elem()
+	for i, cell := range record {
+		cell = strings.TrimSpace(cell)
+		if i >= len(columns) || len(cell) == 0 {
+			continue
+		}
+		if err := setField(params.FieldByIndex(columns[i].index), cell, format); err != nil {
+			row.Errors = append(row.Errors, service.ImportRowError{Row: number, Field: columns[i].name, Message: err.Error()})
+		}
+	}
+	return row
+}
+
+func setField(field reflect.Value, cell string, format string) error {
+	if field.Kind() == reflect.Pointer {
+		value := reflect.New(field.Type().Elem())
+		if err := setField(value.Elem(), cell, format); err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+	}
+	switch field.Interface().(type) {
+	case time.Time:
+		t, err := parseTime(cell, format)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	case []string:
+		var values []string
+		for _, value := range strings.Split(cell, ";") {
+			if value = strings.TrimSpace(value); len(value) > 0 {
+				values = append(values, value)
+			}
+		}
+		field.Set(reflect.ValueOf(values))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(cell)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(strings.ToLower(cell))
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", cell)
+		}
+		field.SetBool(value)
+	case reflect.Int:
+		value, err := strconv.Atoi(cell)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", cell)
+		}
+		field.SetInt(int64(value))
+	case reflect.Float64:
+		value, err := strconv.ParseFloat(cell, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", cell)
+		}
+		field.SetFloat(value)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// parseTime accepts RFC 3339 and plain dates, and in XLSX files also native
+// date cells, which come as serial numbers.
+func parseTime(cell string, format string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, cell); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	if format == FormatXLSX {
+		if serial, err := strconv.ParseFloat(cell, 64); err == nil {
+			if t, err := excelize.ExcelDateToTime(serial, false); err == nil {
+				return t.UTC(), nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date, expected RFC 3339 or YYYY-MM-DD", cell)
+}
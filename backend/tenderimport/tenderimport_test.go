@@ -0,0 +1,213 @@
+package tenderimport
+
+import (
+	"backend/service"
+	"bytes"
+	"github.com/xuri/excelize/v2"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const organizationId = "550e8400-e29b-41d4-a716-446655440000"
+
+func TestParseTime(t *testing.T) {
+	date := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		cell    string
+		format  string
+		want    time.Time
+		wantErr bool
+	}{
+		{"rfc 3339", "2024-10-01T12:30:00Z", FormatCSV, date.Add(12*time.Hour + 30*time.Minute), false},
+		{"rfc 3339 with offset", "2024-10-01T15:00:00+03:00", FormatCSV, date.Add(12 * time.Hour), false},
+		{"date and time", "2024-10-01 12:30:15", FormatCSV, date.Add(12*time.Hour + 30*time.Minute + 15*time.Second), false},
+		{"date and minutes", "2024-10-01 12:30", FormatCSV, date.Add(12*time.Hour + 30*time.Minute), false},
+		{"date", "2024-10-01", FormatCSV, date, false},
+		{"xlsx serial", "45566", FormatXLSX, date, false},
+		{"xlsx serial with time", "45566.5", FormatXLSX, date.Add(12 * time.Hour), false},
+		{"serial in csv", "45566", FormatCSV, time.Time{}, true},
+		{"garbage", "tomorrow", FormatXLSX, time.Time{}, true},
+		{"day first", "01.10.2024", FormatCSV, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTime(tt.cell, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Errorf("parseTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetField(t *testing.T) {
+	deadline := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	budget := 1500.5
+	currency := "RUB"
+	tests := []struct {
+		name    string
+		field   string
+		cell    string
+		want    any
+		wantErr string
+	}{
+		{"string", "Name", "Roads", "Roads", ""},
+		{"list", "ServiceType", "Construction; Delivery;;", []string{"Construction", "Delivery"}, ""},
+		{"bool", "BudgetPublic", "TRUE", true, ""},
+		{"bad bool", "BudgetPublic", "yes", false, `"yes" is not a boolean`},
+		{"int", "AuctionExtension", "60", 60, ""},
+		{"bad int", "AuctionExtension", "1.5", 0, `"1.5" is not an integer`},
+		{"float pointer", "Budget", "1500.5", &budget, ""},
+		{"bad float", "Budget", "a lot", (*float64)(nil), `"a lot" is not a number`},
+		{"string pointer", "Currency", "RUB", &currency, ""},
+		{"time pointer", "Deadline", "2024-10-01", &deadline, ""},
+		{"bad time", "Deadline", "soon", (*time.Time)(nil), `"soon" is not a date, expected RFC 3339 or YYYY-MM-DD`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params service.CreateTenderParams
+			field := reflect.ValueOf(&params).Elem().FieldByName(tt.field)
+			err := setField(field, tt.cell, FormatCSV)
+			if len(tt.wantErr) > 0 {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("setField() error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("setField() error = %v", err)
+			}
+			if got := field.Interface(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("setField() set %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	deadline := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	budget := 100.0
+	currency := "RUB"
+	tests := []struct {
+		name    string
+		file    string
+		want    []service.ImportRow
+		wantErr string
+	}{
+		{
+			name: "rows",
+			file: "Name,description,serviceType,organizationId,deadline,budget,currency\n" +
+				"Roads,Fix roads,Construction;Delivery," + organizationId + ",2024-10-01,100,RUB\n" +
+				",,,,,,\n" +
+				"Bridge,Build a bridge,Construction," + organizationId + ",,,\n",
+			want: []service.ImportRow{
+				{Row: 2, Params: service.CreateTenderParams{
+					Name:           "Roads",
+					Description:    "Fix roads",
+					ServiceType:    []string{"Construction", "Delivery"},
+					OrganizationId: organizationId,
+					Deadline:       &deadline,
+					Budget:         &budget,
+					Currency:       &currency,
+				}},
+				{Row: 4, Params: service.CreateTenderParams{
+					Name:           "Bridge",
+					Description:    "Build a bridge",
+					ServiceType:    []string{"Construction"},
+					OrganizationId: organizationId,
+				}},
+			},
+		},
+		{
+			name: "cell errors",
+			file: "name,budget,deadline\nRoads,cheap,later\n",
+			want: []service.ImportRow{
+				{Row: 2, Params: service.CreateTenderParams{Name: "Roads"}, Errors: []service.ImportRowError{
+					{Row: 2, Field: "budget", Message: `"cheap" is not a number`},
+					{Row: 2, Field: "deadline", Message: `"later" is not a date, expected RFC 3339 or YYYY-MM-DD`},
+				}},
+			},
+		},
+		{
+			name: "extra cells are ignored",
+			file: "name\nRoads,unexpected\n",
+			want: []service.ImportRow{{Row: 2, Params: service.CreateTenderParams{Name: "Roads"}}},
+		},
+		{name: "empty file", file: "", wantErr: "file is empty"},
+		{name: "unknown column", file: "name,price\n", wantErr: `unknown column "price"`},
+		{name: "duplicate column", file: "name,Name\n", wantErr: `duplicate column "Name"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.file), FormatCSV)
+			if len(tt.wantErr) > 0 {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Parse() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseXLSX(t *testing.T) {
+	file := excelize.NewFile()
+	sheet := file.GetSheetList()[0]
+	rows := [][]any{
+		{"name", "deadline", "auctionExtensionSeconds"},
+		{"Roads", time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), 60},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := file.SetSheetRow(sheet, cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := file.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Parse(&buf, FormatXLSX)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	deadline := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	want := []service.ImportRow{{Row: 2, Params: service.CreateTenderParams{
+		Name:             "Roads",
+		Deadline:         &deadline,
+		AuctionExtension: 60,
+	}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     string
+		wantErr  bool
+	}{
+		{"tenders.csv", FormatCSV, false},
+		{"Tenders.XLSX", FormatXLSX, false},
+		{"tenders.xls", "", true},
+		{"tenders", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			got, err := Format(tt.filename)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("Format() = %q, %v, want %q, wantErr %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
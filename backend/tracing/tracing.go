@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"backend/config"
+	"context"
+	"fmt"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+	"google.golang.org/grpc/stats"
+)
+
+type Tracing struct {
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+}
+
+func newExporter(cfg config.ConfigTracing) (sdktrace.SpanExporter, error) {
+	switch cfg.GetExporter() {
+	case "otlp":
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.GetEndpoint())}
+		if cfg.IsInsecure() {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		// The client connects lazily, so a missing collector doesn't block startup.
+		return otlptracegrpc.New(context.Background(), options...)
+	case "stdout":
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.GetExporter())
+	}
+}
+
+func NewTracing(lc fx.Lifecycle, c *config.Config) (*Tracing, error) {
+	cfg := c.GetTracingConfig()
+	t := &Tracing{
+		provider: noop.NewTracerProvider(),
+		propagator: propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		),
+	}
+	otel.SetTextMapPropagator(t.propagator)
+	if cfg.GetExporter() == "none" {
+		return t, nil
+	}
+	exporter, err := newExporter(cfg)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(cfg.GetServiceName()),
+		)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.GetSampleRatio()))),
+	)
+	lc.Append(fx.Hook{
+		OnStop: provider.Shutdown,
+	})
+	t.provider = provider
+	otel.SetTracerProvider(provider)
+	return t, nil
+}
+
+func (t *Tracing) Tracer(name string) trace.Tracer {
+	return t.provider.Tracer(name)
+}
+
+func (t *Tracing) GRPCHandler() stats.Handler {
+	return otelgrpc.NewServerHandler(
+		otelgrpc.WithTracerProvider(t.provider),
+		otelgrpc.WithPropagators(t.propagator),
+	)
+}